@@ -0,0 +1,148 @@
+// Command lucidegen generates a go package from a Lucide icon tree, suitable
+// for go:generate:
+//
+//	//go:generate go run github.com/bryanvaz/go-lucide/cmd/lucidegen -src ../third_party/lucide -icons house,pen,loader-circle
+//
+// With -scan, only the icons referenced (through the generated root package)
+// by the .templ and .go files of a directory are generated. Icons only looked
+// up by name (DataURI, Draw, FS, IconNodes, Rasterize) are not found, and are
+// reported with a warning:
+//
+//	//go:generate go run github.com/bryanvaz/go-lucide/cmd/lucidegen -src ../third_party/lucide -scan ../..
+//
+// The output directory gets a root package (icons.go) exposing every selected
+// icon and alias, and an icons subpackage with the components and runtime
+// helpers. When -module is omitted, the import path of the output directory
+// is derived from the nearest go.mod.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/modfile"
+)
+
+func main() {
+	srcPath := flag.String("src", "", "lucide repo checkout, or directory of svg (and json) files")
+	outPath := flag.String("out", ".", "output directory")
+	module := flag.String("module", "", "import path of the output directory (default: derived from go.mod)")
+	pkg := flag.String("pkg", lucidegen.DEFAULT_PACKAGE, "package name of the generated root package")
+	iconList := flag.String("icons", "", "comma separated icon names or aliases to generate (default: all)")
+	scanPath := flag.String("scan", "", "only generate the icons referenced by the .templ and .go files in this directory")
+	generator := flag.String("generator", lucidegen.DEFAULT_GENERATOR, "generator to run ("+strings.Join(lucidegen.Generators(), ", ")+")")
+	setName := flag.String("name", lucidegen.DEFAULT_SET_NAME, "name of the icon set used in doc comments")
+	version := flag.String("version", "", "version of the icons written to VERSION (optional)")
+	optimize := flag.Bool("optimize", false, "optimize the svgs (whitespace, numbers, shapes) and report the savings")
+	strokeWidth := flag.Float64("stroke-width", 0, "stroke width of the outlined glyphs of the font generator (default: the icons' stroke width)")
+	flag.Parse()
+
+	if *srcPath == "" {
+		fmt.Fprintln(os.Stderr, "lucidegen: -src is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *iconList != "" && *scanPath != "" {
+		fmt.Fprintln(os.Stderr, "lucidegen: -icons and -scan cannot be used together")
+		os.Exit(2)
+	}
+	if err := run(*srcPath, *outPath, *module, *pkg, *iconList, *scanPath, *generator, *setName, *version, *optimize, *strokeWidth); err != nil {
+		fmt.Fprintln(os.Stderr, "lucidegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(srcPath, outPath, module, pkg, iconList, scanPath, generator, setName, version string, optimize bool, strokeWidth float64) error {
+	icons, err := lucidegen.IngestSource(srcPath)
+	if err != nil {
+		return err
+	}
+	if iconList != "" {
+		icons, err = lucidegen.Filter(icons, strings.Split(iconList, ",")...)
+		if err != nil {
+			return err
+		}
+	}
+	if module == "" {
+		module, err = findImportPath(outPath)
+		if err != nil {
+			return err
+		}
+	}
+	if scanPath != "" {
+		icons, err = scanIcons(icons, scanPath, outPath, module, pkg)
+		if err != nil {
+			return err
+		}
+	}
+	return lucidegen.Generate(icons, lucidegen.Options{
+		Generator:   generator,
+		Dir:         outPath,
+		Module:      module,
+		Package:     pkg,
+		SetName:     setName,
+		Version:     version,
+		Optimize:    optimize,
+		StrokeWidth: strokeWidth,
+	})
+}
+
+// findImportPath returns the import path of the directory, based on the
+// module path of the nearest go.mod file.
+func findImportPath(dir string) (string, error) {
+	absDir, err := filepathPkg.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for modDir := absDir; ; modDir = filepathPkg.Dir(modDir) {
+		data, err := os.ReadFile(filepathPkg.Join(modDir, "go.mod"))
+		if err == nil {
+			modulePath := modfile.ModulePath(data)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module path in %s", filepathPkg.Join(modDir, "go.mod"))
+			}
+			rel, err := filepathPkg.Rel(modDir, absDir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return modulePath + "/" + filepathPkg.ToSlash(rel), nil
+		}
+		if filepathPkg.Dir(modDir) == modDir {
+			return "", fmt.Errorf("no go.mod found for %s, use -module", dir)
+		}
+	}
+}
+
+// scanIcons returns the icons referenced through the generated package by the
+// files in scanPath, and reports where each one is used.
+func scanIcons(icons []*lucidegen.LucideIconSvg, scanPath, outPath, module, pkg string) ([]*lucidegen.LucideIconSvg, error) {
+	usage, err := lucidegen.ScanUsage(scanPath, module, pkg, outPath)
+	if err != nil {
+		return nil, err
+	}
+	used, unknown := lucidegen.Resolve(icons, usage.Names()...)
+	for _, name := range unknown {
+		switch {
+		case lucidegen.IsNameLookup(name):
+			fmt.Printf("Warning: %s.%s looks up icons by name, icons only used through it are not generated (used in %s)\n", pkg, name, strings.Join(usage[name], ", "))
+		case lucidegen.IsReserved(name):
+		default:
+			fmt.Printf("Warning: %s.%s is not an icon (used in %s)\n", pkg, name, strings.Join(usage[name], ", "))
+		}
+	}
+	fmt.Printf("Found %d icons used in %s:\n", len(used), scanPath)
+	for _, name := range usage.Names() {
+		if !slices.Contains(unknown, name) {
+			fmt.Printf("  %s (%s)\n", name, strings.Join(usage[name], ", "))
+		}
+	}
+	return used, nil
+}
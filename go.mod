@@ -6,17 +6,19 @@ replace github.com/bryanvaz/go-templ-lucide-icons => ./dist/go-templ-lucide-icon
 
 require (
 	github.com/a-h/templ v0.3.833
+	github.com/andybalholm/brotli v1.1.0
 	github.com/bryanvaz/go-templ-lucide-icons v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v2 v2.52.6
 	github.com/google/go-github/v69 v69.0.0
 	golang.org/x/exp v0.0.0-20250210185358-939b2ce775ac
+	golang.org/x/mod v0.23.0
 	golang.org/x/oauth2 v0.26.0
 	golang.org/x/text v0.22.0
+	maragu.dev/gomponents v1.2.0
 )
 
 require (
 	github.com/a-h/parse v0.0.0-20250122154542-74294addb73e // indirect
-	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/klauspost/compress v1.17.9 // indirect
@@ -27,7 +29,6 @@ require (
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.51.0 // indirect
 	github.com/valyala/tcplisten v1.0.0 // indirect
-	golang.org/x/mod v0.23.0 // indirect
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/tools v0.30.0 // indirect
@@ -0,0 +1,13 @@
+// Package lucidegen reads Lucide style icon trees (svg files with optional
+// json metadata) and generates go packages from them.
+//
+// It is used to build the published icon packages, and can be called from
+// other repos to generate a package with only the icons they need:
+//
+//	icons, err := lucidegen.IngestIcons("./lucide")
+//	icons, err = lucidegen.Filter(icons, "house", "pen")
+//	err = lucidegen.Generate(icons, lucidegen.Options{
+//		Dir:    "./internal/icons",
+//		Module: "github.com/acme/app/internal/icons",
+//	})
+package lucidegen
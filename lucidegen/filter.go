@@ -0,0 +1,54 @@
+package lucidegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter returns the icons matching the names, which can be icon names or
+// aliases in kebab case (e.g. "house" or "home") or go function names (e.g.
+// "House"). Icons keep their order, and an error lists any unknown names.
+func Filter(icons []*LucideIconSvg, names ...string) ([]*LucideIconSvg, error) {
+	filtered, unknown := Resolve(icons, names...)
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown icons: %s", strings.Join(unknown, ", "))
+	}
+	return filtered, nil
+}
+
+// Resolve is like Filter, but returns the unknown names instead of failing.
+func Resolve(icons []*LucideIconSvg, names ...string) (found []*LucideIconSvg, unknown []string) {
+	lookup := make(map[string]*LucideIconSvg)
+	for _, icon := range icons {
+		lookup[icon.KebabName()] = icon
+		lookup[icon.CamelCaseName()] = icon
+	}
+	for _, icon := range icons {
+		for _, alias := range icon.LucideAliases {
+			if _, ok := lookup[string(alias)]; !ok {
+				lookup[string(alias)] = icon
+			}
+			if _, ok := lookup[alias.CamelCaseName()]; !ok {
+				lookup[alias.CamelCaseName()] = icon
+			}
+		}
+	}
+
+	selected := make(map[*LucideIconSvg]bool)
+	for _, name := range names {
+		icon, ok := lookup[strings.TrimSpace(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected[icon] = true
+	}
+
+	found = []*LucideIconSvg{}
+	for _, icon := range icons {
+		if selected[icon] {
+			found = append(found, icon)
+		}
+	}
+	return found, unknown
+}
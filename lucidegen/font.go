@@ -0,0 +1,376 @@
+package lucidegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"maps"
+	"math"
+	"os"
+	filepathPkg "path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	fontUnitsPerEm = 1000
+	fontAscent     = 850
+	fontDescent    = -150
+	// Codepoints are assigned in the private use area of the BMP
+	fontFirstCodepoint = 0xE000
+	fontLastCodepoint  = 0xF8FF
+)
+
+// Persisted name to codepoint table of the font generator
+const CODEPOINTS_FILE = "codepoints.json"
+
+const fontCssTemplate = `@font-face {
+  font-family: "{{ .SetName }}";
+  src: url("fonts/{{ .FileName }}.woff2") format("woff2"),
+    url("fonts/{{ .FileName }}.ttf") format("truetype");
+  font-weight: normal;
+  font-style: normal;
+  font-display: block;
+}
+
+[class^="{{ .ClassPrefix }}"],
+[class*=" {{ .ClassPrefix }}"] {
+  font-family: "{{ .SetName }}" !important;
+  font-style: normal;
+  font-weight: normal;
+  font-variant: normal;
+  text-transform: none;
+  line-height: 1;
+  speak: never;
+  -webkit-font-smoothing: antialiased;
+  -moz-osx-font-smoothing: grayscale;
+}
+{{ range .Classes }}
+.{{ $.ClassPrefix }}{{ .Name }}::before {
+  content: "\{{ .Hex }}";
+}
+{{- end }}
+`
+
+const fontGoTemplate = `package {{ .Package }}
+
+import "embed"
+
+// Assets holds the {{ .SetName }} icon font and its stylesheet:
+// {{ .FileName }}.css, fonts/{{ .FileName }}.ttf and fonts/{{ .FileName }}.woff2.
+//
+//go:embed {{ .FileName }}.css fonts
+var Assets embed.FS
+
+// Codepoints of the {{ .SetName }} icon font glyphs
+const (
+{{- range .Consts }}
+	{{ .Name }} rune = 0x{{ .Hex }} // {{ .Comment }}
+{{- end }}
+)
+`
+
+type fontCodepoint struct {
+	Name    string
+	Hex     string
+	Comment string
+}
+
+type fontFileTemplateParams struct {
+	SetName     string
+	Package     string
+	FileName    string
+	ClassPrefix string
+	Classes     []fontCodepoint
+	Consts      []fontCodepoint
+}
+
+// fontGenerator writes an icon font of the icons (TTF and WOFF2) with their
+// strokes outlined, a stylesheet with a class per icon and the codepoints as
+// go constants. Codepoints are persisted in codepoints.json so that they stay
+// the same across releases.
+type fontGenerator struct{}
+
+func (fontGenerator) Name() string {
+	return "font"
+}
+
+func (fontGenerator) Generate(in GeneratorInput) error {
+	fileName := setFileName(in.SetName)
+	fontsPath := filepathPkg.Join(in.Path, "fonts")
+	fmt.Println("Cleaning up old font files...")
+	if err := resetDir(fontsPath); err != nil {
+		return err
+	}
+	if in.GoMod {
+		if err := writeGoModFile(in.Path, in.Module); err != nil {
+			return err
+		}
+	}
+
+	codepointsPath := filepathPkg.Join(in.Path, CODEPOINTS_FILE)
+	codepoints, err := readCodepoints(codepointsPath)
+	if err != nil {
+		return err
+	}
+	if err := assignCodepoints(codepoints, in.Icons); err != nil {
+		return err
+	}
+	if err := writeCodepoints(codepointsPath, codepoints); err != nil {
+		return err
+	}
+
+	fmt.Printf("Outlining %d icons ...\n", len(in.Icons))
+	font := &sfntFont{
+		FamilyName: in.SetName,
+		Version:    fontVersion(in.Version),
+		UnitsPerEm: fontUnitsPerEm,
+		Ascent:     fontAscent,
+		Descent:    fontDescent,
+	}
+	for _, icon := range in.Icons {
+		contours, err := iconGlyph(icon, in.StrokeWidth)
+		if err != nil {
+			return fmt.Errorf("error outlining %s: %w", icon.Basename(), err)
+		}
+		font.Glyphs = append(font.Glyphs, sfntGlyph{Codepoint: codepoints[icon.KebabName()], Contours: contours})
+	}
+	slices.SortFunc(font.Glyphs, func(a, b sfntGlyph) int { return int(a.Codepoint - b.Codepoint) })
+	tables, err := font.tables()
+	if err != nil {
+		return err
+	}
+	ttf := writeTTF(tables)
+	woff2, err := writeWOFF2(tables, len(ttf))
+	if err != nil {
+		return fmt.Errorf("error compressing font: %w", err)
+	}
+	for _, file := range []struct {
+		ext  string
+		data []byte
+	}{{".ttf", ttf}, {".woff2", woff2}} {
+		path := filepathPkg.Join(fontsPath, fileName+file.ext)
+		if err := os.WriteFile(path, file.data, 0644); err != nil {
+			return fmt.Errorf("error writing to font file %s: %w", path, err)
+		}
+		fmt.Printf("Font saved to %s (%d bytes)\n", path, len(file.data))
+	}
+
+	params := fontFileTemplateParams{
+		SetName:     in.SetName,
+		Package:     in.Package,
+		FileName:    fileName,
+		ClassPrefix: fileName + "-font-",
+	}
+	consts := map[string]fontCodepoint{}
+	for i, names := range iconNames(in.Icons) {
+		icon := in.Icons[i]
+		hex := strconv.FormatInt(int64(codepoints[icon.KebabName()]), 16)
+		params.Classes = append(params.Classes, fontCodepoint{Name: icon.KebabName(), Hex: hex})
+		consts[icon.CamelCaseName()] = fontCodepoint{Name: icon.CamelCaseName(), Hex: hex, Comment: icon.KebabName()}
+		for _, alias := range names[1:] {
+			params.Classes = append(params.Classes, fontCodepoint{Name: alias, Hex: hex})
+			consts[funcName(alias)] = fontCodepoint{Name: funcName(alias), Hex: hex, Comment: "alias of " + icon.KebabName()}
+		}
+	}
+	slices.SortFunc(params.Classes, func(a, b fontCodepoint) int { return strings.Compare(a.Name, b.Name) })
+	for _, name := range slices.Sorted(maps.Keys(consts)) {
+		params.Consts = append(params.Consts, consts[name])
+	}
+
+	css, err := executeTemplate(fontCssTemplate, params)
+	if err != nil {
+		return fmt.Errorf("error creating stylesheet: %w", err)
+	}
+	cssPath := filepathPkg.Join(in.Path, fileName+".css")
+	if err := os.WriteFile(cssPath, css, 0644); err != nil {
+		return fmt.Errorf("error writing to stylesheet: %w", err)
+	}
+	fmt.Println("Stylesheet saved to", cssPath)
+
+	goFile, err := executeTemplate(fontGoTemplate, params)
+	if err != nil {
+		return fmt.Errorf("error creating codepoints file: %w", err)
+	}
+	if goFile, err = format.Source(goFile); err != nil {
+		return fmt.Errorf("error formatting codepoints file: %w", err)
+	}
+	goPath := filepathPkg.Join(in.Path, "codepoints.go")
+	if err := os.WriteFile(goPath, goFile, 0644); err != nil {
+		return fmt.Errorf("error writing to codepoints file: %w", err)
+	}
+	fmt.Println("Codepoints saved to", goPath)
+	return nil
+}
+
+func executeTemplate(text string, data any) ([]byte, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// fontVersion turns the version of the icons into a font version, e.g.
+// 0.469.0 into 0.469.
+func fontVersion(version string) string {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return "1.000"
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// readCodepoints reads the persisted codepoints table, a json object of
+// kebab case names to hex codepoints. It is empty if the file doesn't exist.
+func readCodepoints(path string) (map[string]rune, error) {
+	codepoints := map[string]rune{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return codepoints, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading codepoints: %w", err)
+	}
+	table := map[string]string{}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	used := map[rune]string{}
+	for name, hex := range table {
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || v < fontFirstCodepoint || v > fontLastCodepoint {
+			return nil, fmt.Errorf("%s: invalid codepoint %q for %s", path, hex, name)
+		}
+		if other, ok := used[rune(v)]; ok {
+			return nil, fmt.Errorf("%s: codepoint %s is used by both %s and %s", path, hex, min(name, other), max(name, other))
+		}
+		used[rune(v)] = name
+		codepoints[name] = rune(v)
+	}
+	return codepoints, nil
+}
+
+// assignCodepoints gives the icons that don't have one the next unused
+// codepoints, in order. Codepoints of removed icons are kept in the table, so
+// that they are never reused for another icon. An icon renamed with its
+// previous name as alias (e.g. home to house) takes over the codepoint of that
+// name, which its alias class and constant keep using.
+func assignCodepoints(codepoints map[string]rune, icons []*LucideIconSvg) error {
+	next := rune(fontFirstCodepoint)
+	for _, cp := range codepoints {
+		next = max(next, cp+1)
+	}
+	names := map[string]bool{}
+	for _, icon := range icons {
+		names[icon.KebabName()] = true
+	}
+	for _, icon := range icons {
+		if _, ok := codepoints[icon.KebabName()]; ok {
+			continue
+		}
+		for _, alias := range icon.LucideAliases {
+			if cp, ok := codepoints[string(alias)]; ok && !names[string(alias)] {
+				delete(codepoints, string(alias))
+				codepoints[icon.KebabName()] = cp
+				break
+			}
+		}
+	}
+	for _, icon := range icons {
+		if _, ok := codepoints[icon.KebabName()]; ok {
+			continue
+		}
+		if next > fontLastCodepoint {
+			return fmt.Errorf("no codepoint left for %s", icon.KebabName())
+		}
+		codepoints[icon.KebabName()] = next
+		next++
+	}
+	return nil
+}
+
+func writeCodepoints(path string, codepoints map[string]rune) error {
+	table := map[string]string{}
+	for name, cp := range codepoints {
+		table[name] = strconv.FormatInt(int64(cp), 16)
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing codepoints: %w", err)
+	}
+	return nil
+}
+
+// iconGlyph outlines the icon and maps its viewBox to the em square, from the
+// descender to the ascender.
+func iconGlyph(icon *LucideIconSvg, strokeWidth float64) ([][]glyphPoint, error) {
+	svg, err := icon.Svg()
+	if err != nil {
+		return nil, err
+	}
+	viewBox := []float64{0, 0, 24, 24}
+	value, _ := svg.Attr("viewBox")
+	if fields := strings.Fields(strings.ReplaceAll(value, ",", " ")); len(fields) == 4 {
+		for i, field := range fields {
+			if viewBox[i], err = strconv.ParseFloat(field, 64); err != nil {
+				return nil, fmt.Errorf("invalid viewBox %q", value)
+			}
+		}
+	}
+	size := math.Max(viewBox[2], viewBox[3])
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid viewBox %q", value)
+	}
+	contours, err := outlineSvg(svg, strokeWidth, size/96)
+	if err != nil {
+		return nil, err
+	}
+	scale := fontUnitsPerEm / size
+	offsetX := (size - viewBox[2]) / 2 * scale
+	offsetY := (size - viewBox[3]) / 2 * scale
+
+	glyph := [][]glyphPoint{}
+	for _, c := range contours {
+		points := []glyphPoint{}
+		for _, p := range c.Points {
+			gp := glyphPoint{
+				X:       int(math.Round((p.X-viewBox[0])*scale + offsetX)),
+				Y:       int(math.Round(fontAscent - (p.Y-viewBox[1])*scale - offsetY)),
+				OnCurve: !c.Curved,
+			}
+			if n := len(points); n > 0 && points[n-1] == gp {
+				continue
+			}
+			points = append(points, gp)
+		}
+		if n := len(points); n > 1 && points[0] == points[n-1] {
+			points = points[:n-1]
+		}
+		// y points up in the font, so the area of a contour changes sign. Tiny
+		// contours that rounding flips would cut through the others.
+		if len(points) < 3 || float64(glyphArea(points))*contourArea(c) >= 0 {
+			continue
+		}
+		glyph = append(glyph, points)
+	}
+	return glyph, nil
+}
+
+func glyphArea(points []glyphPoint) int {
+	area := 0
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += p.X*q.Y - p.Y*q.X
+	}
+	return area
+}
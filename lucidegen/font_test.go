@@ -0,0 +1,50 @@
+package lucidegen
+
+import (
+	"os"
+	filepathPkg "path/filepath"
+	"testing"
+)
+
+func TestAssignCodepointsRename(t *testing.T) {
+	path := filepathPkg.Join(t.TempDir(), CODEPOINTS_FILE)
+	table := `{"home": "e000", "pen": "e001", "trash": "e002"}`
+	if err := os.WriteFile(path, []byte(table), 0644); err != nil {
+		t.Fatal(err)
+	}
+	codepoints, err := readCodepoints(path)
+	if err != nil {
+		t.Fatalf("readCodepoints() error = %v", err)
+	}
+
+	// home is renamed to house, pen is removed and circle is new
+	icons := []*LucideIconSvg{
+		{LucideIconSvgPath: "icons/circle.svg"},
+		{LucideIconSvgPath: "icons/house.svg", LucideAliases: []LucideIconAlias{"home"}},
+		{LucideIconSvgPath: "icons/trash.svg", LucideAliases: []LucideIconAlias{"pen"}},
+	}
+	if err := assignCodepoints(codepoints, icons); err != nil {
+		t.Fatalf("assignCodepoints() error = %v", err)
+	}
+	want := map[string]rune{"house": 0xe000, "pen": 0xe001, "trash": 0xe002, "circle": 0xe003}
+	if len(codepoints) != len(want) {
+		t.Errorf("assignCodepoints() = %x, want %x", codepoints, want)
+	}
+	for name, cp := range want {
+		if codepoints[name] != cp {
+			t.Errorf("codepoint of %s = %x, want %x", name, codepoints[name], cp)
+		}
+	}
+
+	// the table is read back with the renamed icon
+	if err := writeCodepoints(path, codepoints); err != nil {
+		t.Fatalf("writeCodepoints() error = %v", err)
+	}
+	again, err := readCodepoints(path)
+	if err != nil {
+		t.Fatalf("readCodepoints() error = %v", err)
+	}
+	if again["house"] != 0xe000 {
+		t.Errorf("persisted codepoint of house = %x, want e000", again["house"])
+	}
+}
@@ -0,0 +1,396 @@
+package lucidegen
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	filepathPkg "path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/bryanvaz/go-lucide/src"
+)
+
+const (
+	DEFAULT_GENERATOR = "templ"
+	DEFAULT_PACKAGE   = "icons"
+	DEFAULT_SET_NAME  = "Lucide"
+
+	TEMPL_PACKAGE      = "github.com/a-h/templ"
+	GOMPONENTS_PACKAGE = "maragu.dev/gomponents"
+)
+
+// Versions required by generated packages when the build info is unavailable
+var defaultModuleVersions = map[string]string{
+	TEMPL_PACKAGE:      "v0.3.833",
+	GOMPONENTS_PACKAGE: "v1.2.0",
+}
+
+// Generator writes one output flavor (e.g. templ components) of an icon set.
+type Generator interface {
+	// Name used to select the generator
+	Name() string
+	// Generate writes the output tree for the icons into in.Path
+	Generate(in GeneratorInput) error
+}
+
+// GeneratorInput is the validated icon set handed to a generator.
+type GeneratorInput struct {
+	// Human readable name of the icon set used in the generated doc comments
+	SetName string
+	// Upstream version of the icons
+	Version string
+	Icons   []*LucideIconSvg
+	// Root directory of the output tree
+	Path string
+	// Import path of the output tree
+	Module string
+	// Package name of the root (rollup) package
+	Package string
+	// Create a go.mod file for Module in Path if there is none
+	GoMod bool
+	// Stroke width of the outlined glyphs of the font generator, 0 keeps the
+	// stroke width of the icons
+	StrokeWidth float64
+}
+
+var generators = []Generator{
+	templGenerator{},
+	templGoGenerator{},
+	gomponentsGenerator{},
+	fontGenerator{},
+}
+
+// Generators returns the names of the available generators.
+func Generators() []string {
+	names := []string{}
+	for _, gen := range generators {
+		names = append(names, gen.Name())
+	}
+	return names
+}
+
+// RegisterGenerator adds a generator (e.g. an output flavor of another
+// project) to the generators selected by name, see Options.Generator.
+func RegisterGenerator(gen Generator) error {
+	if gen.Name() == "" {
+		return fmt.Errorf("generator has no name")
+	}
+	if _, err := FindGenerator(gen.Name()); err == nil {
+		return fmt.Errorf("generator '%s' is already registered", gen.Name())
+	}
+	generators = append(generators, gen)
+	return nil
+}
+
+// FindGenerator returns the generator with the given name.
+func FindGenerator(name string) (Generator, error) {
+	for _, gen := range generators {
+		if gen.Name() == name {
+			return gen, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown generator '%s' (available: %s)", name, strings.Join(Generators(), ", "))
+}
+
+// Options configures Generate.
+type Options struct {
+	// Name of the generator, defaults to templ (see RegisterGenerator for
+	// generators of other packages)
+	Generator string
+	// Output directory
+	Dir string
+	// Import path of the output directory
+	Module string
+	// Package name of the root package, defaults to icons
+	Package string
+	// Name of the icon set used in doc comments, defaults to Lucide
+	SetName string
+	// Version of the icons written to the VERSION file (optional)
+	Version string
+	// Create a go.mod file in Dir if there is none
+	GoMod bool
+	// Optimize the svgs before generating, see OptimizeIcons
+	Optimize bool
+	// Stroke width of the glyphs of the font generator (optional)
+	StrokeWidth float64
+}
+
+// Generate validates the icons and writes the package produced by the
+// selected generator into opts.Dir.
+func Generate(icons []*LucideIconSvg, opts Options) error {
+	if opts.Generator == "" {
+		opts.Generator = DEFAULT_GENERATOR
+	}
+	if opts.Package == "" {
+		opts.Package = DEFAULT_PACKAGE
+	}
+	if opts.SetName == "" {
+		opts.SetName = DEFAULT_SET_NAME
+	}
+	if opts.Dir == "" {
+		return fmt.Errorf("no output directory")
+	}
+	if opts.Module == "" {
+		return fmt.Errorf("no module path for %s", opts.Dir)
+	}
+	gen, err := FindGenerator(opts.Generator)
+	if err != nil {
+		return err
+	}
+	if len(icons) == 0 {
+		return fmt.Errorf("no icons to generate")
+	}
+	if err := ValidateIconNames(icons); err != nil {
+		return err
+	}
+	warnReservedNames(icons)
+	if opts.Optimize {
+		var results []OptimizeResult
+		icons, results, err = OptimizeIcons(icons)
+		if err != nil {
+			return err
+		}
+		PrintOptimizeReport(os.Stdout, results)
+	}
+	if err := os.MkdirAll(opts.Dir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+	err = gen.Generate(GeneratorInput{
+		SetName:     opts.SetName,
+		Version:     opts.Version,
+		Icons:       icons,
+		Path:        opts.Dir,
+		Module:      opts.Module,
+		Package:     opts.Package,
+		GoMod:       opts.GoMod,
+		StrokeWidth: opts.StrokeWidth,
+	})
+	if err != nil {
+		return fmt.Errorf("%s generator: %w", gen.Name(), err)
+	}
+	if opts.Version != "" {
+		if err := os.WriteFile(filepathPkg.Join(opts.Dir, "VERSION"), []byte(opts.Version), 0644); err != nil {
+			return fmt.Errorf("error writing to VERSION file: %w", err)
+		}
+	}
+	return nil
+}
+
+// componentFlavor is an output flavor of the icon components (e.g. templ),
+// generated by generateComponents.
+type componentFlavor struct {
+	// Name of the flavor in the progress messages
+	name string
+	// Runtime dependency required by the go.mod of the package
+	require string
+	// Runtime helpers copied into the icons subpackage
+	runtime []string
+	// Signature of the icon functions of the rollup
+	signature   rollupSignature
+	typeAliases []string
+	// Files written into the icons subpackage along with the icons (name,
+	// content). They use both the flavor and the runtime helpers, so they are
+	// written with the icons rather than copied from the runtime.
+	files [][2]string
+	// writeIcon writes the file(s) of an icon into the icons subpackage
+	writeIcon func(iconsPath string, icon *LucideIconSvg, setName string) error
+}
+
+// generateComponents writes the components of the icons of a flavor into the
+// icons subpackage, along with the runtime helpers, the root rollup file, the
+// stylesheet and the svg files.
+func generateComponents(in GeneratorInput, flavor componentFlavor) error {
+	iconsPath := filepathPkg.Join(in.Path, "icons")
+	fmt.Printf("Cleaning up old %s files...\n", flavor.name)
+	if err := resetDir(iconsPath); err != nil {
+		return err
+	}
+	if in.GoMod {
+		if err := writeGoModFile(in.Path, in.Module, flavor.require); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Generating %s files for %d icons ...\n", flavor.name, len(in.Icons))
+	for _, icon := range in.Icons {
+		if err := flavor.writeIcon(iconsPath, icon, in.SetName); err != nil {
+			return err
+		}
+	}
+	for _, file := range flavor.files {
+		if err := os.WriteFile(filepathPkg.Join(iconsPath, file[0]), []byte(file[1]), 0644); err != nil {
+			return fmt.Errorf("error writing to %s: %w", file[0], err)
+		}
+	}
+
+	if err := writeNodesFile(iconsPath, in.Icons); err != nil {
+		return err
+	}
+
+	// Copy the runtime helpers
+	if err := copyRuntimeFiles(iconsPath, flavor.runtime...); err != nil {
+		return fmt.Errorf("error copying runtime helpers: %w", err)
+	}
+
+	rollupFile, err := createRollupFile(in.Icons, in.Package, in.Module, in.SetName, flavor.signature, flavor.typeAliases...)
+	if err != nil {
+		return fmt.Errorf("error creating rollup file: %w", err)
+	}
+	rollupFilePath := filepathPkg.Join(in.Path, "icons.go")
+	if err := os.WriteFile(rollupFilePath, []byte(rollupFile), 0644); err != nil {
+		return fmt.Errorf("error writing to rollup file: %w", err)
+	}
+	fmt.Println("Rollup file saved to", rollupFilePath)
+
+	if err := writeStylesheet(in.Path, in.Icons, in.SetName); err != nil {
+		return err
+	}
+
+	return writeSvgFiles(in.Path, in.Icons)
+}
+
+// Files written into the icons subpackage besides the runtime helpers and the
+// icons
+var generatedFiles = []string{"icon_nodes.go", "svg_component.go", "svg_templ.go"}
+
+// iconFileBase returns the base name of the files of an icon in the icons
+// subpackage: its kebab case name, suffixed like the function of a reserved
+// name if a file of the runtime helpers has the same name (e.g. node-icon.go,
+// since node.go holds the Node type).
+func iconFileBase(icon *LucideIconSvg) string {
+	for _, ext := range []string{".go", "_templ.go"} {
+		name := icon.Basename() + ext
+		matches, _ := fs.Glob(src.Runtime, "*/"+name)
+		if len(matches) > 0 || slices.Contains(generatedFiles, name) {
+			return icon.Basename() + "-" + strings.ToLower(RESERVED_NAME_SUFFIX)
+		}
+	}
+	return icon.Basename()
+}
+
+// iconFileTemplateParams are the parameters of the template of the file of
+// an icon, shared by the flavors.
+type iconFileTemplateParams struct {
+	SetName       string
+	SvgName       string
+	SvgDecl       string
+	NodeDecl      string
+	FuncName      string
+	ContentName   string
+	KebabCaseName string
+	Content       string
+}
+
+// executeIconTemplate renders the file of an icon with the template of a
+// flavor, the markup of its shapes being rendered by content.
+func executeIconTemplate(tmpl *template.Template, icon *LucideIconSvg, setName string, content func(*Svg) string) ([]byte, error) {
+	svg, err := icon.Svg()
+	if err != nil {
+		return nil, err
+	}
+	funcName := icon.CamelCaseName()
+	contentName := lowerFirst(funcName) + "Content"
+	svgName, svgDecl := svgIconCode(icon, svg, contentName)
+	_, nodeDecl := iconNodeCode(icon, svg)
+	data := iconFileTemplateParams{
+		SetName:       setName,
+		SvgName:       svgName,
+		SvgDecl:       svgDecl,
+		NodeDecl:      nodeDecl,
+		FuncName:      funcName,
+		ContentName:   contentName,
+		KebabCaseName: icon.Basename(),
+		Content:       content(svg),
+	}
+	var outputBuffer bytes.Buffer
+	if err := tmpl.Execute(&outputBuffer, data); err != nil {
+		return nil, err
+	}
+	return outputBuffer.Bytes(), nil
+}
+
+// resetDir deletes the directory if it exists and creates it again empty.
+func resetDir(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("error deleting folder: %w", err)
+		}
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+	return nil
+}
+
+// setFileName returns the name of the icon set as used in file names and css
+// classes, e.g. lucide-lab for Lucide Lab.
+func setFileName(setName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(setName), "-"))
+}
+
+// writeGoModFile creates a go.mod file for the generated package, requiring
+// the given runtime dependencies, unless the output directory already
+// contains one.
+func writeGoModFile(outputPath string, module string, requires ...string) error {
+	goModPath := filepathPkg.Join(outputPath, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		return nil
+	}
+	goMod := []string{
+		"module " + module,
+		"",
+		"go 1.23",
+		"",
+	}
+	for _, require := range requires {
+		goMod = append(goMod, "require "+require+" "+moduleVersion(require), "")
+	}
+	if err := os.WriteFile(goModPath, []byte(strings.Join(goMod, "\n")), 0644); err != nil {
+		return fmt.Errorf("error writing go.mod file: %w", err)
+	}
+	fmt.Printf("Created %s, run `go mod tidy` in %s before building\n", goModPath, outputPath)
+	return nil
+}
+
+// moduleVersion returns the version of a dependency used by the generator, so
+// that the generated package requires a compatible runtime.
+func moduleVersion(path string) string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == path && dep.Version != "(devel)" {
+				return dep.Version
+			}
+		}
+	}
+	return defaultModuleVersions[path]
+}
+
+// copyRuntimeFiles copies the runtime helpers of the flavors (e.g. common,
+// gomponents) into the icons subpackage.
+func copyRuntimeFiles(dstDir string, flavors ...string) error {
+	for _, flavor := range flavors {
+		files, err := fs.ReadDir(src.Runtime, flavor)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			name := file.Name()
+			if file.IsDir() || filepathPkg.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			data, err := fs.ReadFile(src.Runtime, flavor+"/"+name)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepathPkg.Join(dstDir, name), data, 0644); err != nil {
+				return err
+			}
+			fmt.Println("Copied", name)
+		}
+	}
+	return nil
+}
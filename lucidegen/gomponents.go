@@ -0,0 +1,91 @@
+package lucidegen
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+	"text/template"
+)
+
+const templateGomponentsFile = `package icons
+
+import g "maragu.dev/gomponents"
+
+{{ .SvgDecl }}
+
+// Renders the {{ .SetName }} icon {{ .KebabCaseName }}.
+func {{ .FuncName }}(attrs ...Attrs) g.Node {
+	return svgNode({{ .SvgName }}, attrs)
+}
+
+const {{ .ContentName }} = {{ printf "%q" .Content }}
+
+{{ .NodeDecl }}
+`
+
+var tmplGomponentsFileGen *template.Template
+
+func generateGomponentsFile(icon *LucideIconSvg, setName string) (string, error) {
+	var err error
+	if tmplGomponentsFileGen == nil {
+		tmplGomponentsFileGen, err = template.New("gomponentsFile").Parse(templateGomponentsFile)
+		if err != nil {
+			return "", err
+		}
+	}
+	output, err := executeIconTemplate(tmplGomponentsFileGen, icon, setName, func(svg *Svg) string {
+		return strings.ReplaceAll(svg.Markup(), "\n", "")
+	})
+	if err != nil {
+		return "", err
+	}
+	formattedOutput, err := format.Source(output)
+	if err != nil {
+		return "", err
+	}
+	return string(formattedOutput), nil
+}
+
+var gomponentsRollupSignature = rollupSignature{
+	Imports: []string{`g "maragu.dev/gomponents"`},
+	Params:  "attrs ...Attrs",
+	Args:    "attrs...",
+	Result:  "g.Node",
+	Funcs: `// Styles renders the stylesheet of the icon animations and css custom
+// properties (see AnimationCSS and ThemeCSS), with the given nonce for content
+// security policies if it is not empty.
+func Styles(nonce string) g.Node {
+	return g.El("style", g.If(nonce != "", g.Attr("nonce", nonce)), g.Raw(AnimationCSS+ThemeCSS))
+}`,
+}
+
+// gomponentsGenerator writes the gomponents functions of the icons into the
+// icons subpackage, along with the runtime helpers and the root rollup file.
+type gomponentsGenerator struct{}
+
+func (gomponentsGenerator) Name() string {
+	return "gomponents"
+}
+
+func (gomponentsGenerator) Generate(in GeneratorInput) error {
+	return generateComponents(in, componentFlavor{
+		name:        "gomponents",
+		require:     GOMPONENTS_PACKAGE,
+		runtime:     []string{"common", "gomponents"},
+		signature:   gomponentsRollupSignature,
+		typeAliases: []string{"Attrs"},
+		writeIcon: func(iconsPath string, icon *LucideIconSvg, setName string) error {
+			goFile, err := generateGomponentsFile(icon, setName)
+			if err != nil {
+				return fmt.Errorf("error generating gomponents file for %s: %w", icon.Basename(), err)
+			}
+			outputGoPath := filepathPkg.Join(iconsPath, iconFileBase(icon)+".go")
+			if err := os.WriteFile(outputGoPath, []byte(goFile), 0644); err != nil {
+				return fmt.Errorf("error writing to output file %s: %w", outputGoPath, err)
+			}
+			return nil
+		},
+	})
+}
@@ -1,4 +1,4 @@
-package main
+package lucidegen
 
 import (
 	"encoding/json"
@@ -19,12 +19,31 @@ func kebabToCamelCase(input string) string {
 	return strings.Join(words, "")
 }
 
+// Suffix of the function of an icon (or alias) whose name is reserved
+const RESERVED_NAME_SUFFIX = "Icon"
+
+// funcName returns the go function name of an icon or alias name. Names
+// reserved by the generated package get a suffix, e.g. the function of a node
+// icon is NodeIcon since Node is a type of the runtime.
+func funcName(name string) string {
+	camel := kebabToCamelCase(name)
+	if IsReserved(camel) {
+		return camel + RESERVED_NAME_SUFFIX
+	}
+	return camel
+}
+
+// LucideIconAlias is an alternative (usually deprecated) kebab case name of an
+// icon.
 type LucideIconAlias string
 
+// LucideIconSvg is an icon read from an svg file.
 type LucideIconSvg struct {
 	LucideIconSvgPath string
 	LucideSvgContent  string
 	LucideAliases     []LucideIconAlias
+
+	svg *Svg
 }
 
 func (i *LucideIconSvg) KebabName() string {
@@ -32,7 +51,7 @@ func (i *LucideIconSvg) KebabName() string {
 }
 
 func (i *LucideIconSvg) CamelCaseName() string {
-	return kebabToCamelCase(i.KebabName())
+	return funcName(i.KebabName())
 }
 func (i *LucideIconSvg) Basename() string {
 	return strings.TrimSuffix(filepathPkg.Base(i.LucideIconSvgPath), ".svg")
@@ -41,12 +60,40 @@ func (i *LucideIconSvg) LucideClasses() string {
 	return "lucide lucide-" + i.Basename()
 }
 
+// Svg returns the parsed svg of the icon.
+func (i *LucideIconSvg) Svg() (*Svg, error) {
+	if i.svg == nil {
+		svg, err := ParseSvg(i.LucideIconSvgPath, i.LucideSvgContent)
+		if err != nil {
+			return nil, err
+		}
+		i.svg = svg
+	}
+	return i.svg, nil
+}
+
 func (a *LucideIconAlias) CamelCaseName() string {
-	return kebabToCamelCase(string(*a))
+	return funcName(string(*a))
 }
 
-func injestIcons(lucideRepoPath string) ([]*LucideIconSvg, error) {
-	iconsPath := filepathPkg.Join(lucideRepoPath, "icons")
+// IngestIcons reads the icons of a lucide repo (or any repo with the same
+// layout), found in its icons directory.
+func IngestIcons(lucideRepoPath string) ([]*LucideIconSvg, error) {
+	return IngestIconsDir(filepathPkg.Join(lucideRepoPath, "icons"))
+}
+
+// IngestSource reads the icons of a lucide repo checkout (a directory with an
+// icons subdirectory), or of a flat directory of svg (and json) files.
+func IngestSource(dir string) ([]*LucideIconSvg, error) {
+	if info, err := os.Stat(filepathPkg.Join(dir, "icons")); err == nil && info.IsDir() {
+		return IngestIcons(dir)
+	}
+	return IngestIconsDir(dir)
+}
+
+// IngestIconsDir reads every svg file in the directory, along with the
+// aliases from the json metadata file of the same name if there is one.
+func IngestIconsDir(iconsPath string) ([]*LucideIconSvg, error) {
 	files, err := os.ReadDir(iconsPath)
 	if err != nil {
 		return nil, err
@@ -90,14 +137,18 @@ func injestIcons(lucideRepoPath string) ([]*LucideIconSvg, error) {
 						aliases = append(aliases, LucideIconAlias(aliasObj.Name))
 						continue
 					}
-					fmt.Errorf("Error unmarshalling alias '%s' in file %s", alias, jsonFilePath)
+					fmt.Printf("Error unmarshalling alias '%s' in file %s\n", alias, jsonFilePath)
 				}
 			}
-			svgFiles = append(svgFiles, &LucideIconSvg{
+			icon := &LucideIconSvg{
 				LucideIconSvgPath: filepath,
 				LucideSvgContent:  string(content),
 				LucideAliases:     aliases,
-			})
+			}
+			if _, err := icon.Svg(); err != nil {
+				return nil, err
+			}
+			svgFiles = append(svgFiles, icon)
 
 		}
 	}
@@ -0,0 +1,186 @@
+package lucidegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	filepathPkg "path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Names exported by the runtime helpers and the rollup, that can't be the
+// function of an icon
+var reservedNames = []string{"AbsolutePath", "AnimationCSS", "Assets", "Attr", "AttrError", "Attrs", "DataURI", "DataURIOptions", "DataURISvg", "DefaultSizeScale", "Draw", "DrawSvg", "FS", "FlattenShape", "IconNode", "IconNodes", "Node", "ParsePathData", "ParsePoints", "PathCommand", "PathPoint", "PathSubpath", "RasterOptions", "Rasterize", "SetCSSVars", "SetSizeScale", "SetStrictAttrs", "Size", "SizeScale", "Styles", "ThemeCSS", "WithSizeScale"}
+
+// Functions of the rollup looking up icons by name at runtime, whose icons
+// can't be found by ScanUsage
+var nameLookupFuncs = []string{"DataURI", "Draw", "FS", "IconNodes", "Rasterize"}
+
+// IsReserved returns true if the name is exported by the generated root
+// package but isn't an icon (e.g. Attrs or Styles). Icons with such a name get
+// RESERVED_NAME_SUFFIX appended to their function.
+func IsReserved(name string) bool {
+	return slices.Contains(reservedNames, name)
+}
+
+// IsNameLookup returns true if the name is a function (or file system) of the
+// generated root package looking up icons by their name at runtime, such as
+// DataURI("house"). The icons it is called with are not referenced in the
+// code, so ScanUsage can't find them.
+func IsNameLookup(name string) bool {
+	return slices.Contains(nameLookupFuncs, name)
+}
+
+// Types of the runtime helpers exposed by the rollup of every flavor
+var nodeTypeAliases = []string{"IconNode", "Node", "Attr", "RasterOptions", "DataURIOptions", "AttrError", "Size", "SizeScale"}
+
+const nodesFileTemplate = `package icons
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// IconNodes returns the shapes of the icon with the given kebab case name or
+// alias. Using it links the shapes of every icon into the binary.
+func IconNodes(name string) (IconNode, bool) {
+	switch name {
+	{{- range .Nodes }}
+	case {{ .Cases }}:
+		return {{ .Var }}, true
+	{{- end }}
+	}
+	return nil, false
+}
+
+// iconRootAttrs returns the root attributes of the icon with the given kebab
+// case name or alias.
+func iconRootAttrs(name string) rootAttrs {
+	{{- with .RootAttrs }}
+	switch name {
+	{{- range . }}
+	case {{ .Cases }}:
+		return {{ .Var }}
+	{{- end }}
+	}
+	{{- end }}
+	return defaultRootAttrs
+}
+
+// Rasterize renders the icon with the given name or alias into a new
+// size x size image, with the given color and stroke width (0 keeps the
+// icon's stroke width). It returns nil if there is no such icon.
+func Rasterize(name string, size int, c color.Color, strokeWidth float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	if err := Draw(img, img.Bounds(), name, RasterOptions{Color: c, StrokeWidth: strokeWidth}); err != nil {
+		return nil
+	}
+	return img
+}
+
+// Draw renders the icon with the given name or alias over dst, scaled to fit
+// in r. Strokes always have round caps and joins, like the Lucide icons.
+// Using Draw or Rasterize links the shapes of every icon into the binary.
+func Draw(dst draw.Image, r image.Rectangle, name string, opts RasterOptions) error {
+	node, ok := IconNodes(name)
+	if !ok {
+		return fmt.Errorf("unknown icon %q", name)
+	}
+	return drawIcon(dst, r, node, iconRootAttrs(name), opts)
+}
+
+// DataURI returns the svg of the icon with the given name or alias as a url
+// encoded data URI, for css (url("...")) or img src attributes. Using it links
+// the shapes of every icon into the binary.
+func DataURI(name string, opts DataURIOptions) (string, bool) {
+	node, ok := IconNodes(name)
+	if !ok {
+		return "", false
+	}
+	return svgDataURI(iconRootAttrs(name), node, opts), true
+}
+`
+
+// iconNodeCode returns the name and declaration of the variable holding the
+// shapes of the icon.
+func iconNodeCode(icon *LucideIconSvg, svg *Svg) (name string, decl string) {
+	name = lowerFirst(icon.CamelCaseName()) + "Node"
+	lines := []string{
+		fmt.Sprintf("// Shapes of the %s icon", icon.KebabName()),
+		"var " + name + " = IconNode{",
+	}
+	for _, el := range svg.Elements {
+		attrs := []string{}
+		for _, attr := range el.Attrs {
+			attrs = append(attrs, fmt.Sprintf("{%q, %q}", attr.Name, attr.Value))
+		}
+		lines = append(lines, fmt.Sprintf("\t{Tag: %q, Attrs: []Attr{%s}},", el.Tag, strings.Join(attrs, ", ")))
+	}
+	lines = append(lines, "}")
+	return name, strings.Join(lines, "\n")
+}
+
+// createNodesFile creates the icons subpackage file looking up the shapes and
+// root attributes of the icons by name, rasterizing them and encoding them as
+// data URIs.
+func createNodesFile(icons []*LucideIconSvg) (string, error) {
+	tmplNodesFileGen, err := template.New("nodesTemplate").Parse(nodesFileTemplate)
+	if err != nil {
+		return "", err
+	}
+	type nodeCase struct {
+		Cases string
+		Var   string
+	}
+	cases := []nodeCase{}
+	rootAttrsCases := []nodeCase{}
+	for i, names := range iconNames(icons) {
+		icon := icons[i]
+		quoted := []string{}
+		for _, name := range names {
+			quoted = append(quoted, fmt.Sprintf("%q", name))
+		}
+		cases = append(cases, nodeCase{
+			Cases: strings.Join(quoted, ", "),
+			Var:   lowerFirst(icon.CamelCaseName()) + "Node",
+		})
+		svg, err := icon.Svg()
+		if err != nil {
+			return "", err
+		}
+		if rootAttrs, _ := rootAttrsCode(icon, svg); !svg.HasLucideRootAttrs() {
+			rootAttrsCases = append(rootAttrsCases, nodeCase{Cases: strings.Join(quoted, ", "), Var: rootAttrs})
+		}
+	}
+	data := struct {
+		Nodes     []nodeCase
+		RootAttrs []nodeCase
+	}{cases, rootAttrsCases}
+	var outputBuffer bytes.Buffer
+	if err := tmplNodesFileGen.Execute(&outputBuffer, data); err != nil {
+		return "", err
+	}
+	formattedOutput, err := format.Source(outputBuffer.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return string(formattedOutput), nil
+}
+
+// writeNodesFile writes the shape lookup into the icons subpackage.
+func writeNodesFile(iconsPath string, icons []*LucideIconSvg) error {
+	nodesFile, err := createNodesFile(icons)
+	if err != nil {
+		return fmt.Errorf("error creating nodes file: %w", err)
+	}
+	nodesFilePath := filepathPkg.Join(iconsPath, "icon_nodes.go")
+	if err := os.WriteFile(nodesFilePath, []byte(nodesFile), 0644); err != nil {
+		return fmt.Errorf("error writing to nodes file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package lucidegen
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Attributes holding a single number, which can be shortened
+var numberAttrs = map[string]bool{
+	"x": true, "y": true, "width": true, "height": true, "rx": true, "ry": true,
+	"cx": true, "cy": true, "r": true, "x1": true, "y1": true, "x2": true, "y2": true,
+}
+
+// OptimizeResult is the outcome of optimizing an icon.
+type OptimizeResult struct {
+	Name string
+	// Size in bytes of the child elements before and after optimization
+	Before int
+	After  int
+	// Set when the icon could not be optimized and was left as is
+	Err error
+}
+
+// OptimizeIcons returns copies of the icons with optimized svgs: whitespace
+// and numbers are written as compactly as possible, and lines, polylines,
+// polygons and square rects are converted to paths when that is shorter.
+// Every optimized shape is checked to have the same geometry as the original
+// one; icons that fail the check are left untouched.
+func OptimizeIcons(icons []*LucideIconSvg) ([]*LucideIconSvg, []OptimizeResult, error) {
+	optimized := []*LucideIconSvg{}
+	results := []OptimizeResult{}
+	for _, icon := range icons {
+		svg, err := icon.Svg()
+		if err != nil {
+			return nil, nil, err
+		}
+		result := OptimizeResult{Name: icon.KebabName(), Before: len(svg.Markup())}
+		optimizedSvg, err := OptimizeSvg(svg)
+		if err != nil {
+			result.Err = err
+			optimizedSvg = svg
+		}
+		result.After = len(optimizedSvg.Markup())
+		results = append(results, result)
+
+		copied := *icon
+		copied.svg = optimizedSvg
+		optimized = append(optimized, &copied)
+	}
+	return optimized, results, nil
+}
+
+// OptimizeSvg returns an optimized copy of the svg, see OptimizeIcons.
+func OptimizeSvg(svg *Svg) (*Svg, error) {
+	optimized := &Svg{RootAttrs: svg.RootAttrs}
+	for _, el := range svg.Elements {
+		optimizedEl, err := optimizeElement(el)
+		if err != nil {
+			return nil, fmt.Errorf("<%s>: %w", el.Tag, err)
+		}
+		if err := checkSameShape(el, optimizedEl); err != nil {
+			return nil, err
+		}
+		optimized.Elements = append(optimized.Elements, optimizedEl)
+	}
+	return optimized, nil
+}
+
+func optimizeElement(el SvgElement) (SvgElement, error) {
+	optimized := SvgElement{Tag: el.Tag}
+	for _, attr := range el.Attrs {
+		value := attr.Value
+		switch {
+		case el.Tag == "path" && attr.Name == "d":
+			cmds, err := parsePathData(strings.TrimSpace(value))
+			if err != nil {
+				return el, err
+			}
+			value = formatPathData(cmds)
+		case (el.Tag == "polyline" || el.Tag == "polygon") && attr.Name == "points":
+			points, err := parsePoints(strings.TrimSpace(value))
+			if err != nil {
+				return el, err
+			}
+			value = formatNumbers(points)
+		case numberAttrs[attr.Name]:
+			// values with units are left as is
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				value = formatNumber(v)
+			}
+		}
+		optimized.Attrs = append(optimized.Attrs, SvgAttr{Name: attr.Name, Value: value})
+	}
+	if path, ok := shapeToPath(optimized); ok && len(path.Markup()) < len(optimized.Markup()) {
+		return path, nil
+	}
+	return optimized, nil
+}
+
+// shapeToPath converts lines, polylines, polygons and rects without rounded
+// corners to paths, keeping the other attributes.
+func shapeToPath(el SvgElement) (SvgElement, bool) {
+	if el.Tag == "path" || el.Tag == "circle" || el.Tag == "ellipse" {
+		return el, false
+	}
+	segs, err := geometry(el)
+	if err != nil || len(segs) == 0 || segs[0].Cmd != 'M' {
+		return el, false
+	}
+	if el.Tag == "rect" {
+		w, _ := el.Get("width")
+		h, _ := el.Get("height")
+		// rects without a positive size are not rendered
+		if wf, err := strconv.ParseFloat(w, 64); err != nil || wf <= 0 {
+			return el, false
+		}
+		if hf, err := strconv.ParseFloat(h, 64); err != nil || hf <= 0 {
+			return el, false
+		}
+	}
+	cmds := []pathCommand{}
+	for _, seg := range segs {
+		cmds = append(cmds, pathCommand{Cmd: seg.Cmd, Args: seg.Args})
+	}
+	if el.Tag == "rect" {
+		// M x y h w v h H x z
+		x, y := segs[0].Args[0], segs[0].Args[1]
+		w, h := segs[1].Args[0]-x, segs[2].Args[1]-y
+		cmds = []pathCommand{
+			{Cmd: 'M', Args: []float64{x, y}},
+			{Cmd: 'h', Args: []float64{w}},
+			{Cmd: 'v', Args: []float64{h}},
+			{Cmd: 'H', Args: []float64{x}},
+			{Cmd: 'z'},
+		}
+	}
+	path := SvgElement{Tag: "path", Attrs: []SvgAttr{{Name: "d", Value: formatPathData(cmds)}}}
+	for _, attr := range el.Attrs {
+		if !slices.Contains(geometryAttrs[el.Tag], attr.Name) {
+			path.Attrs = append(path.Attrs, attr)
+		}
+	}
+	return path, true
+}
+
+// checkSameShape verifies that the optimized element renders like the
+// original one: same geometry and same other attributes.
+func checkSameShape(original SvgElement, optimized SvgElement) error {
+	originalSegs, err := geometry(original)
+	if err != nil {
+		return err
+	}
+	optimizedSegs, err := geometry(optimized)
+	if err != nil {
+		return err
+	}
+	if !sameSegments(originalSegs, optimizedSegs) {
+		return fmt.Errorf("<%s>: optimized geometry differs", original.Tag)
+	}
+	if presentationAttrs(original) != presentationAttrs(optimized) {
+		return fmt.Errorf("<%s>: optimized attributes differ", original.Tag)
+	}
+	return nil
+}
+
+// presentationAttrs returns the non geometry attributes of the element.
+func presentationAttrs(el SvgElement) string {
+	attrs := []string{}
+	for _, attr := range el.Attrs {
+		if !slices.Contains(geometryAttrs[el.Tag], attr.Name) {
+			attrs = append(attrs, attr.Name+"="+attr.Value)
+		}
+	}
+	return strings.Join(attrs, " ")
+}
+
+// PrintOptimizeReport writes the savings of each icon and the total.
+func PrintOptimizeReport(w io.Writer, results []OptimizeResult) {
+	before, after := 0, 0
+	for _, result := range results {
+		before += result.Before
+		after += result.After
+		if result.Err != nil {
+			fmt.Fprintf(w, "  %-40s not optimized: %s\n", result.Name, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  %-40s %6d -> %6d bytes (%s)\n", result.Name, result.Before, result.After, savings(result.Before, result.After))
+	}
+	fmt.Fprintf(w, "Optimized %d icons: %d -> %d bytes (%s)\n", len(results), before, after, savings(before, after))
+}
+
+func savings(before int, after int) string {
+	if before == 0 {
+		return "0.0% saved"
+	}
+	return fmt.Sprintf("%.1f%% saved", float64(before-after)*100/float64(before))
+}
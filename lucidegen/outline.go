@@ -0,0 +1,314 @@
+package lucidegen
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	common "github.com/bryanvaz/go-lucide/src/common"
+)
+
+// fpoint is a point in viewBox coordinates.
+type fpoint struct {
+	X, Y float64
+}
+
+func (p fpoint) add(q fpoint) fpoint    { return fpoint{p.X + q.X, p.Y + q.Y} }
+func (p fpoint) sub(q fpoint) fpoint    { return fpoint{p.X - q.X, p.Y - q.Y} }
+func (p fpoint) scale(k float64) fpoint { return fpoint{p.X * k, p.Y * k} }
+func (p fpoint) cross(q fpoint) float64 { return p.X*q.Y - p.Y*q.X }
+func (p fpoint) length() float64        { return math.Hypot(p.X, p.Y) }
+
+// polyline is a flattened subpath.
+type polyline struct {
+	Points []fpoint
+	Closed bool
+}
+
+// contour is a closed outline of a glyph. Curved contours only have off curve
+// (quadratic control) points, which is how circles are drawn.
+type contour struct {
+	Points []fpoint
+	Curved bool
+}
+
+// flattenElement flattens a shape into polylines with the flattener of the
+// rasterizer (see common.FlattenShape), with curves split into segments of about
+// maxLen.
+func flattenElement(node common.Node, maxLen float64) ([]polyline, error) {
+	paths, err := common.FlattenShape(node, maxLen)
+	if err != nil {
+		return nil, err
+	}
+	lines := []polyline{}
+	for _, path := range paths {
+		line := polyline{Closed: path.Closed}
+		for _, p := range path.Points {
+			line.Points = append(line.Points, fpoint{p.X, p.Y})
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// circleContour approximates a circle with 8 quadratic curves.
+func circleContour(c fpoint, r float64) contour {
+	// the implied on curve points, halfway between the control points, are
+	// on the circle
+	rc := r / math.Cos(math.Pi/8)
+	points := []fpoint{}
+	for i := 0; i < 8; i++ {
+		a := math.Pi/8 + float64(i)*math.Pi/4
+		points = append(points, fpoint{c.X + rc*math.Cos(a), c.Y + rc*math.Sin(a)})
+	}
+	return contour{Points: points, Curved: true}
+}
+
+// strokeContours outlines the stroke of the polylines, with round joins and
+// caps. Runs of segments that turn gently (flattened curves) are outlined as
+// a single contour, and a circle is added at their ends and at sharp corners.
+// The contours overlap and all have the same orientation, so that their union
+// is filled (nonzero rule).
+func strokeContours(lines []polyline, hw float64) []contour {
+	contours := []contour{}
+	for _, line := range lines {
+		// drop repeated points, which have no direction
+		points := []fpoint{line.Points[0]}
+		for _, p := range line.Points[1:] {
+			if p.sub(points[len(points)-1]).length() > 1e-9 {
+				points = append(points, p)
+			}
+		}
+		closed := line.Closed
+		if closed && len(points) > 1 && points[len(points)-1].sub(points[0]).length() <= 1e-9 {
+			points = points[:len(points)-1]
+		}
+		if len(points) == 1 {
+			// a zero length subpath is drawn as a dot
+			contours = append(contours, circleContour(points[0], hw))
+			continue
+		}
+		if closed && len(points) == 2 {
+			closed = false
+			points = append(points, points[0])
+		}
+
+		n := len(points)
+		smooth := make([]bool, n)
+		for i := range points {
+			if !closed && (i == 0 || i == n-1) {
+				continue
+			}
+			prev, v, next := points[(i+n-1)%n], points[i], points[(i+1)%n]
+			smooth[i] = smoothJoin(prev, v, next, hw)
+		}
+		if closed {
+			start := slices.Index(smooth, false)
+			if start < 0 {
+				// a closed curve without corners is outlined as a ring
+				outer := contour{Points: offsetPoints(points, hw, true)}
+				inner := contour{Points: offsetPoints(points, -hw, true)}
+				if math.Abs(contourArea(inner)) > math.Abs(contourArea(outer)) {
+					outer, inner = inner, outer
+				}
+				orientContour(&outer, 1)
+				orientContour(&inner, -1)
+				contours = append(contours, outer, inner)
+				continue
+			}
+			// start at a corner, which is then both ends of the polyline
+			points = append(append(points[start:len(points):len(points)], points[:start]...), points[start])
+			smooth = append(append(smooth[start:len(smooth):len(smooth)], smooth[:start]...), false)
+		}
+
+		runStart := 0
+		for i := 1; i < len(points); i++ {
+			if smooth[i] {
+				continue
+			}
+			run := points[runStart : i+1]
+			left := offsetPoints(run, hw, false)
+			right := offsetPoints(run, -hw, false)
+			slices.Reverse(right)
+			c := contour{Points: append(left, right...)}
+			orientContour(&c, 1)
+			contours = append(contours, c)
+			if runStart == 0 {
+				contours = append(contours, circleContour(points[0], hw))
+			}
+			if !closed || i < len(points)-1 {
+				contours = append(contours, circleContour(points[i], hw))
+			}
+			runStart = i
+		}
+	}
+	return contours
+}
+
+// smoothJoin reports whether the polyline turns gently enough at v for both
+// sides of the stroke to be offset without folding.
+func smoothJoin(prev, v, next fpoint, hw float64) bool {
+	d1, d2 := v.sub(prev), next.sub(v)
+	l1, l2 := d1.length(), d2.length()
+	cos := (d1.X*d2.X + d1.Y*d2.Y) / (l1 * l2)
+	if cos < math.Cos(math.Pi/6) {
+		return false
+	}
+	// distance from v to where the offsets of the two segments meet
+	halfAngle := math.Acos(min(cos, 1)) / 2
+	return hw*math.Tan(halfAngle) <= min(l1, l2)/2
+}
+
+// offsetPoints offsets the polyline by d along its normals, meeting at the
+// vertices (which must be smooth joins).
+func offsetPoints(points []fpoint, d float64, closed bool) []fpoint {
+	n := len(points)
+	normal := func(a, b fpoint) fpoint {
+		v := b.sub(a)
+		return fpoint{-v.Y, v.X}.scale(1 / v.length())
+	}
+	offset := []fpoint{}
+	for i, p := range points {
+		var n1, n2 fpoint
+		switch {
+		case closed:
+			n1, n2 = normal(points[(i+n-1)%n], p), normal(p, points[(i+1)%n])
+		case i == 0:
+			n1 = normal(p, points[1])
+			n2 = n1
+		case i == n-1:
+			n1 = normal(points[i-1], p)
+			n2 = n1
+		default:
+			n1, n2 = normal(points[i-1], p), normal(p, points[i+1])
+		}
+		// miter of the two normals
+		m := n1.add(n2)
+		m = m.scale(2 / (m.X*m.X + m.Y*m.Y))
+		offset = append(offset, p.add(m.scale(d)))
+	}
+	return offset
+}
+
+// outlineSvg returns the contours of the icon in viewBox coordinates, with
+// strokes of the given width (0 for the stroke width of the icon) outlined.
+// Outer contours are clockwise when y points down, holes counterclockwise.
+func outlineSvg(svg *Svg, strokeWidth float64, maxLen float64) ([]contour, error) {
+	if strokeWidth <= 0 {
+		strokeWidth = 1
+		value, _ := svg.Attr("stroke-width")
+		if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			strokeWidth = v
+		}
+	}
+	_, nodes := runtimeNodes(svg)
+	contours := []contour{}
+	for i, el := range svg.Elements {
+		segs, err := geometry(el)
+		if err != nil {
+			return nil, err
+		}
+		paint := func(name string, defaultValue string) bool {
+			value, ok := el.Get(name)
+			if !ok {
+				value, _ = svg.Attr(name)
+			}
+			if value == "" {
+				value = defaultValue
+			}
+			value = strings.TrimSpace(value)
+			return value != "none" && value != "transparent"
+		}
+		width := strokeWidth
+		if value, ok := el.Get("stroke-width"); ok {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				width = v
+			}
+		}
+
+		// circles are drawn with curves rather than flattened
+		isCircle := len(segs) == 1 && segs[0].Cmd == 'O' && segs[0].Args[2] == segs[0].Args[3]
+		if isCircle && segs[0].Args[2] <= 0 {
+			continue
+		}
+		var lines []polyline
+		if !isCircle {
+			if lines, err = flattenElement(nodes[i], maxLen); err != nil {
+				return nil, err
+			}
+		}
+
+		if paint("fill", "black") && el.Tag != "line" {
+			if isCircle {
+				contours = append(contours, circleContour(fpoint{segs[0].Args[0], segs[0].Args[1]}, segs[0].Args[2]))
+			} else {
+				contours = append(contours, fillContours(lines)...)
+			}
+		}
+		if paint("stroke", "none") && width > 0 {
+			if isCircle {
+				c, r := fpoint{segs[0].Args[0], segs[0].Args[1]}, segs[0].Args[2]
+				contours = append(contours, circleContour(c, r+width/2))
+				if r > width/2 {
+					hole := circleContour(c, r-width/2)
+					reverseContour(&hole)
+					contours = append(contours, hole)
+				}
+			} else {
+				contours = append(contours, strokeContours(lines, width/2)...)
+			}
+		}
+	}
+	if len(contours) == 0 {
+		return nil, fmt.Errorf("nothing to draw")
+	}
+	return contours, nil
+}
+
+// fillContours returns the subpaths as contours, all reversed if needed so
+// that the filled area is clockwise like the strokes.
+func fillContours(lines []polyline) []contour {
+	contours := []contour{}
+	area := 0.0
+	for _, line := range lines {
+		if len(line.Points) < 3 {
+			continue
+		}
+		c := contour{Points: line.Points}
+		area += contourArea(c)
+		contours = append(contours, c)
+	}
+	if area < 0 {
+		for i := range contours {
+			reverseContour(&contours[i])
+		}
+	}
+	return contours
+}
+
+// contourArea returns the signed area of the contour, positive when it is
+// clockwise (with y pointing down).
+func contourArea(c contour) float64 {
+	area := 0.0
+	for i, p := range c.Points {
+		area += p.cross(c.Points[(i+1)%len(c.Points)])
+	}
+	return area / 2
+}
+
+// orientContour reverses the contour if the sign of its area differs.
+func orientContour(c *contour, sign float64) {
+	if contourArea(*c)*sign < 0 {
+		reverseContour(c)
+	}
+}
+
+func reverseContour(c *contour) {
+	points := make([]fpoint, len(c.Points))
+	for i, p := range c.Points {
+		points[len(points)-1-i] = p
+	}
+	c.Points = points
+}
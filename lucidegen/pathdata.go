@@ -0,0 +1,221 @@
+package lucidegen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	common "github.com/bryanvaz/go-lucide/src/common"
+)
+
+// pathCommand is a single command of path data, parsed by
+// common.ParsePathData.
+type pathCommand = common.PathCommand
+
+// parsePathData parses the d attribute of a path.
+func parsePathData(d string) ([]pathCommand, error) {
+	return common.ParsePathData(d)
+}
+
+// formatNumber returns the shortest representation of the number.
+func formatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if exp := strconv.FormatFloat(v, 'g', -1, 64); len(exp) < len(s) {
+		s = exp
+	}
+	if s == "-0" {
+		return "0"
+	}
+	if strings.HasPrefix(s, "0.") {
+		return s[1:]
+	}
+	if strings.HasPrefix(s, "-0.") {
+		return "-" + s[2:]
+	}
+	return s
+}
+
+// appendNumber writes the number, with a separator only when the previous
+// number would otherwise absorb it.
+func appendNumber(b *strings.Builder, prev string, s string) {
+	if prev != "" && s[0] != '-' && !(s[0] == '.' && strings.ContainsAny(prev, ".eE")) {
+		b.WriteByte(' ')
+	}
+	b.WriteString(s)
+}
+
+// formatPathData writes the path data as compactly as possible without
+// changing its commands or values.
+func formatPathData(cmds []pathCommand) string {
+	var b strings.Builder
+	var prev byte
+	prevNum := ""
+	for _, c := range cmds {
+		implicit := prev
+		if prev == 'M' {
+			implicit = 'L'
+		} else if prev == 'm' {
+			implicit = 'l'
+		}
+		if len(c.Args) == 0 || c.Cmd != implicit {
+			b.WriteByte(c.Cmd)
+			prevNum = ""
+		}
+		for _, arg := range c.Args {
+			s := formatNumber(arg)
+			appendNumber(&b, prevNum, s)
+			prevNum = s
+		}
+		prev = c.Cmd
+	}
+	return b.String()
+}
+
+// parsePoints parses the points attribute of a polyline or polygon.
+func parsePoints(points string) ([]float64, error) {
+	values, err := common.ParsePoints(points)
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, fmt.Errorf("odd number of coordinates in points")
+	}
+	return values, nil
+}
+
+func formatNumbers(values []float64) string {
+	var b strings.Builder
+	prev := ""
+	for _, v := range values {
+		s := formatNumber(v)
+		appendNumber(&b, prev, s)
+		prev = s
+	}
+	return b.String()
+}
+
+// segment is a command of the canonical geometry of a shape, in absolute
+// coordinates. Paths and polygonal shapes use M, L, C, Q, A and Z (see
+// common.AbsolutePath), circles and ellipses O (cx cy rx ry) and rounded
+// rectangles R (x y w h rx ry).
+type segment = common.PathCommand
+
+// Attributes that define the geometry of each shape
+var geometryAttrs = map[string][]string{
+	"path":     {"d"},
+	"circle":   {"cx", "cy", "r"},
+	"rect":     {"x", "y", "width", "height", "rx", "ry"},
+	"line":     {"x1", "y1", "x2", "y2"},
+	"polyline": {"points"},
+	"polygon":  {"points"},
+	"ellipse":  {"cx", "cy", "rx", "ry"},
+}
+
+// geometry returns the canonical geometry of the element, so that shapes
+// written differently but rendering the same compare equal.
+func geometry(el SvgElement) ([]segment, error) {
+	num := func(name string) (float64, error) {
+		value, ok := el.Get(name)
+		if !ok {
+			return 0, nil
+		}
+		return strconv.ParseFloat(strings.TrimSpace(value), 64)
+	}
+	nums := func(names ...string) ([]float64, error) {
+		values := []float64{}
+		for _, name := range names {
+			v, err := num(name)
+			if err != nil {
+				return nil, fmt.Errorf("<%s %s>: %w", el.Tag, name, err)
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	polygonal := func(values []float64, closed bool) []segment {
+		segs := []segment{}
+		for i := 0; i+1 < len(values); i += 2 {
+			cmd := byte('L')
+			if i == 0 {
+				cmd = 'M'
+			}
+			segs = append(segs, segment{Cmd: cmd, Args: values[i : i+2]})
+		}
+		if closed {
+			segs = append(segs, segment{Cmd: 'Z'})
+		}
+		return segs
+	}
+
+	switch el.Tag {
+	case "path":
+		d, _ := el.Get("d")
+		cmds, err := parsePathData(d)
+		if err != nil {
+			return nil, err
+		}
+		return common.AbsolutePath(cmds), nil
+	case "line":
+		v, err := nums("x1", "y1", "x2", "y2")
+		if err != nil {
+			return nil, err
+		}
+		return polygonal(v, false), nil
+	case "polyline", "polygon":
+		points, _ := el.Get("points")
+		v, err := parsePoints(points)
+		if err != nil {
+			return nil, err
+		}
+		return polygonal(v, el.Tag == "polygon"), nil
+	case "rect":
+		v, err := nums("x", "y", "width", "height", "rx", "ry")
+		if err != nil {
+			return nil, err
+		}
+		x, y, w, h, rx, ry := v[0], v[1], v[2], v[3], v[4], v[5]
+		_, hasRx := el.Get("rx")
+		_, hasRy := el.Get("ry")
+		if hasRx && !hasRy {
+			ry = rx
+		} else if hasRy && !hasRx {
+			rx = ry
+		}
+		if rx == 0 && ry == 0 {
+			return polygonal([]float64{x, y, x + w, y, x + w, y + h, x, y + h}, true), nil
+		}
+		return []segment{{Cmd: 'R', Args: []float64{x, y, w, h, rx, ry}}}, nil
+	case "circle":
+		v, err := nums("cx", "cy", "r")
+		if err != nil {
+			return nil, err
+		}
+		return []segment{{Cmd: 'O', Args: []float64{v[0], v[1], v[2], v[2]}}}, nil
+	case "ellipse":
+		v, err := nums("cx", "cy", "rx", "ry")
+		if err != nil {
+			return nil, err
+		}
+		return []segment{{Cmd: 'O', Args: v}}, nil
+	}
+	return nil, fmt.Errorf("unsupported element <%s>", el.Tag)
+}
+
+// sameSegments compares geometries, allowing for floating point rounding.
+func sameSegments(a []segment, b []segment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmd != b[i].Cmd || len(a[i].Args) != len(b[i].Args) {
+			return false
+		}
+		for j := range a[i].Args {
+			if math.Abs(a[i].Args[j]-b[i].Args[j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+package lucidegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"golang.org/x/exp/slices"
+)
+
+const rollupFileTemplate = `
+package {{ .Package }}
+
+import (
+	"embed"
+	"image"
+	"image/color"
+	"image/draw"
+	"io/fs"
+	"path"
+	{{- range .Signature.Imports }}
+	{{ . }}
+	{{- end }}
+	iconFuncs "{{ .Module }}/icons"
+)
+
+//go:embed svg
+var svgFiles embed.FS
+
+// FS holds the svg file of every icon and alias, named after its kebab case
+// name (e.g. house.svg). Aliases are copies of the svg of their icon.
+var FS fs.FS = svgFS{}
+
+// svgFS serves the svg directory of svgFiles. Unlike fs.Sub, it needs no
+// initialization, so the files are only linked into binaries using FS.
+type svgFS struct{}
+
+func (svgFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return svgFiles.Open(path.Join("svg", name))
+}
+{{ range .TypeAliases }}
+// {{ . }} is an alias for icons.{{ . }}.
+type {{ . }} = iconFuncs.{{ . }}
+{{ end }}
+// IconNodes returns the shapes of the icon with the given kebab case name or
+// alias. Using it links the shapes of every icon into the binary.
+func IconNodes(name string) (IconNode, bool) {
+	return iconFuncs.IconNodes(name)
+}
+
+// Rasterize renders the icon with the given name or alias into a new
+// size x size image, with the given color and stroke width (0 keeps the
+// icon's stroke width). It returns nil if there is no such icon.
+func Rasterize(name string, size int, c color.Color, strokeWidth float64) *image.RGBA {
+	return iconFuncs.Rasterize(name, size, c, strokeWidth)
+}
+
+// Draw renders the icon with the given name or alias over dst, scaled to fit
+// in r. Strokes always have round caps and joins, like the Lucide icons.
+func Draw(dst draw.Image, r image.Rectangle, name string, opts RasterOptions) error {
+	return iconFuncs.Draw(dst, r, name, opts)
+}
+
+// DrawSvg renders shapes over dst, scaled to fit in r, like Draw. The root
+// node holds the attributes of the svg element (viewBox, fill, stroke, ...).
+func DrawSvg(dst draw.Image, r image.Rectangle, root Node, node IconNode, opts RasterOptions) error {
+	return iconFuncs.DrawSvg(dst, r, root, node, opts)
+}
+
+// DataURI returns the svg of the icon with the given name or alias as a url
+// encoded data URI, for css (url("...")) or img src attributes.
+func DataURI(name string, opts DataURIOptions) (string, bool) {
+	return iconFuncs.DataURI(name, opts)
+}
+
+// DataURISvg returns the svg of the shapes as a url encoded data URI, like
+// DataURI. The root node holds the attributes of the svg element.
+func DataURISvg(root Node, node IconNode, opts DataURIOptions) string {
+	return iconFuncs.DataURISvg(root, node, opts)
+}
+
+// AnimationCSS is the stylesheet of the icon animations, see Styles.
+const AnimationCSS = iconFuncs.AnimationCSS
+
+// ThemeCSS is the stylesheet of the css custom properties of the icons, see
+// SetCSSVars and Styles.
+const ThemeCSS = iconFuncs.ThemeCSS
+
+{{ with .Signature.Funcs }}{{ . }}
+
+{{ end }}// DefaultSizeScale returns the default size scale, from xs (12px) to 2xl
+// (40px).
+func DefaultSizeScale() SizeScale {
+	return iconFuncs.DefaultSizeScale()
+}
+
+// SetSizeScale sets the size scale of the icons, for named sizes like
+// "size": "sm". It is meant to be set once at startup.
+func SetSizeScale(scale SizeScale) {
+	iconFuncs.SetSizeScale(scale)
+}
+
+// SetCSSVars sets whether the icons are rendered with css custom properties
+// for their default size, stroke color and stroke width (--lucide-size,
+// --lucide-stroke and --lucide-stroke-width) applied by the rules of ThemeCSS,
+// see icons.SetCSSVars.
+func SetCSSVars(enabled bool) {
+	iconFuncs.SetCSSVars(enabled)
+}
+
+// SetStrictAttrs sets whether rendering an icon fails with an *AttrError when
+// it is passed an attribute it doesn't recognize, see icons.SetStrictAttrs.
+func SetStrictAttrs(strict bool) {
+	iconFuncs.SetStrictAttrs(strict)
+}
+
+{{ .Content }}
+`
+
+// rollupSignature describes the icon functions of a flavor, so that the
+// rollup can wrap them.
+type rollupSignature struct {
+	Imports []string
+	Params  string
+	Args    string
+	Result  string
+	// Funcs are the functions of the flavor in the rollup, besides the icons
+	Funcs string
+}
+
+// createRollupFile creates the root package file exposing every icon (and
+// alias) of the icons subpackage, along with the given type aliases.
+//
+// Icons are exposed as functions wrapping the icons subpackage (rather than
+// package level variables), so each one is an independent symbol that the
+// linker can drop when it is not called.
+func createRollupFile(icons []*LucideIconSvg, pkg string, module string, setName string, sig rollupSignature, typeAliases ...string) (string, error) {
+	tmplRollupFileGen, err := template.New("rollupTemplate").Parse(rollupFileTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	type tmplParams struct {
+		Package     string
+		Module      string
+		Signature   rollupSignature
+		TypeAliases []string
+		Content     string
+	}
+	rollupFunc := func(name string, target string) string {
+		return fmt.Sprintf("func %s(%s) %s {\n\treturn iconFuncs.%s(%s)\n}", name, sig.Params, sig.Result, target, sig.Args)
+	}
+	rollupLines := map[string][]string{}
+	funcNames := []string{}
+	for _, icon := range icons {
+		lines := []string{}
+		lines = append(lines, fmt.Sprintf("// Renders the %s icon '%s'.", setName, icon.Basename()))
+		lines = append(lines, rollupFunc(icon.CamelCaseName(), icon.CamelCaseName()))
+		rollupLines[icon.CamelCaseName()] = lines
+		funcNames = append(funcNames, icon.CamelCaseName())
+	}
+	for i, names := range iconNames(icons) {
+		icon := icons[i]
+		for _, alias := range names[1:] {
+			aliasName := funcName(alias)
+			aliasLines := []string{}
+			aliasLines = append(aliasLines, fmt.Sprintf("// Alias for '%s'(%s).Renders the %s icon '%s'", icon.CamelCaseName(), icon.Basename(), setName, alias))
+			aliasLines = append(aliasLines, rollupFunc(aliasName, icon.CamelCaseName()))
+			rollupLines[aliasName] = aliasLines
+			funcNames = append(funcNames, aliasName)
+		}
+	}
+	slices.Sort(funcNames)
+	content := ""
+	for _, funcName := range funcNames {
+		content += strings.Join(rollupLines[funcName], "\n") + "\n\n"
+	}
+	typeAliases = append(append([]string{}, nodeTypeAliases...), typeAliases...)
+	params := tmplParams{Package: pkg, Module: module, Signature: sig, TypeAliases: typeAliases, Content: content}
+	var outputBuffer bytes.Buffer
+	if err := tmplRollupFileGen.Execute(&outputBuffer, params); err != nil {
+		return "", err
+	}
+	formattedOutput, err := format.Source(outputBuffer.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	return string(formattedOutput), nil
+}
@@ -0,0 +1,153 @@
+package lucidegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	filepathPkg "path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ/generator"
+	parser "github.com/a-h/templ/parser/v2"
+	"golang.org/x/exp/slices"
+)
+
+// Usage maps the identifiers referenced through an icon package (e.g. House)
+// to the files that reference them.
+type Usage map[string][]string
+
+// Names returns the referenced identifiers, sorted.
+func (u Usage) Names() []string {
+	names := []string{}
+	for name := range u {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ScanUsage walks the .templ and .go files under root and collects every
+// identifier referenced through an import of importPath, whose package name
+// is packageName (used when the import has no explicit name). Templ files are
+// compiled to go with the templ generator before being inspected, and
+// generated _templ.go files are skipped when their .templ source exists.
+// Directories in skipDirs (and hidden, vendor and node_modules directories)
+// are not scanned.
+func ScanUsage(root string, importPath string, packageName string, skipDirs ...string) (Usage, error) {
+	skip := map[string]bool{}
+	for _, dir := range skipDirs {
+		absDir, err := filepathPkg.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		skip[absDir] = true
+	}
+	usage := Usage{}
+	err := filepathPkg.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			absPath, err := filepathPkg.Abs(path)
+			if err != nil {
+				return err
+			}
+			name := d.Name()
+			if skip[absPath] || name == "vendor" || name == "node_modules" || (path != root && strings.HasPrefix(name, ".")) {
+				return filepathPkg.SkipDir
+			}
+			return nil
+		}
+		var src []byte
+		switch {
+		case strings.HasSuffix(path, "_templ.go"):
+			if _, err := os.Stat(strings.TrimSuffix(path, "_templ.go") + ".templ"); err == nil {
+				return nil
+			}
+			if src, err = os.ReadFile(path); err != nil {
+				return err
+			}
+		case strings.HasSuffix(path, ".go"):
+			if src, err = os.ReadFile(path); err != nil {
+				return err
+			}
+		case strings.HasSuffix(path, ".templ"):
+			if src, err = templToGo(path); err != nil {
+				return fmt.Errorf("error compiling %s: %w", path, err)
+			}
+		default:
+			return nil
+		}
+		names, err := referencedNames(path, src, importPath, packageName)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			usage[name] = append(usage[name], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func templToGo(path string) ([]byte, error) {
+	t, err := parser.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if _, err := generator.Generate(t, &b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// referencedNames returns the identifiers selected from the package imported
+// as importPath (e.g. House for icons.House) in the go source.
+func referencedNames(path string, src []byte, importPath string, packageName string) ([]string, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, path, src, goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	localName := ""
+	for _, imp := range file.Imports {
+		impPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || impPath != importPath {
+			continue
+		}
+		if imp.Name != nil {
+			localName = imp.Name.Name
+		} else {
+			localName = packageName
+		}
+	}
+	if localName == "" || localName == "_" {
+		return nil, nil
+	}
+	if localName == "." {
+		return nil, fmt.Errorf("%s: dot imports of %s are not supported", path, importPath)
+	}
+	seen := map[string]bool{}
+	names := []string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == localName && !seen[sel.Sel.Name] {
+			seen[sel.Sel.Name] = true
+			names = append(names, sel.Sel.Name)
+		}
+		return true
+	})
+	return names, nil
+}
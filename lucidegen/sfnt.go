@@ -0,0 +1,503 @@
+package lucidegen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"unicode/utf16"
+
+	"github.com/andybalholm/brotli"
+)
+
+// glyphPoint is a point of a TrueType glyph outline, in font units (y up).
+type glyphPoint struct {
+	X, Y    int
+	OnCurve bool
+}
+
+// sfntGlyph is a glyph of an icon font, mapped to a single codepoint.
+type sfntGlyph struct {
+	Codepoint rune
+	Contours  [][]glyphPoint
+}
+
+// sfntFont is a TrueType font where every glyph is a square of UnitsPerEm,
+// from Descent to Ascent.
+type sfntFont struct {
+	FamilyName string
+	Version    string
+	UnitsPerEm int
+	Ascent     int
+	Descent    int
+	Glyphs     []sfntGlyph
+}
+
+type sfntTable struct {
+	Tag  string
+	Data []byte
+}
+
+type fontWriter struct {
+	bytes.Buffer
+}
+
+func (w *fontWriter) u8(v int)  { w.WriteByte(byte(v)) }
+func (w *fontWriter) u16(v int) { w.Write(binary.BigEndian.AppendUint16(nil, uint16(v))) }
+func (w *fontWriter) u32(v int) { w.Write(binary.BigEndian.AppendUint32(nil, uint32(v))) }
+func (w *fontWriter) pad4() {
+	for w.Len()%4 != 0 {
+		w.WriteByte(0)
+	}
+}
+
+type glyphBounds struct {
+	xMin, yMin, xMax, yMax int
+}
+
+func contoursBounds(contours [][]glyphPoint) glyphBounds {
+	b := glyphBounds{math.MaxInt, math.MaxInt, math.MinInt, math.MinInt}
+	for _, c := range contours {
+		for _, p := range c {
+			b.xMin, b.yMin = min(b.xMin, p.X), min(b.yMin, p.Y)
+			b.xMax, b.yMax = max(b.xMax, p.X), max(b.yMax, p.Y)
+		}
+	}
+	return b
+}
+
+// encodeGlyph writes a simple glyph description, or nothing for an empty
+// glyph.
+func encodeGlyph(contours [][]glyphPoint) ([]byte, error) {
+	if len(contours) == 0 {
+		return nil, nil
+	}
+	var w fontWriter
+	b := contoursBounds(contours)
+	w.u16(len(contours))
+	for _, v := range []int{b.xMin, b.yMin, b.xMax, b.yMax} {
+		w.u16(v)
+	}
+	end := -1
+	for _, c := range contours {
+		end += len(c)
+		w.u16(end)
+	}
+	if end >= math.MaxUint16 {
+		return nil, fmt.Errorf("too many points in glyph (%d)", end+1)
+	}
+	w.u16(0) // no instructions
+	var flags, xs, ys []byte
+	x, y := 0, 0
+	coord := func(delta int, short byte, same byte, out []byte) (byte, []byte) {
+		switch {
+		case delta == 0:
+			return same, out
+		case delta > -256 && delta < 256:
+			if delta > 0 {
+				return short | same, append(out, byte(delta))
+			}
+			return short, append(out, byte(-delta))
+		}
+		return 0, binary.BigEndian.AppendUint16(out, uint16(int16(delta)))
+	}
+	for _, c := range contours {
+		for _, p := range c {
+			var flag, xFlag, yFlag byte
+			if p.OnCurve {
+				flag = 0x01
+			}
+			if len(flags) == 0 {
+				// contours overlap (OVERLAP_SIMPLE)
+				flag |= 0x40
+			}
+			xFlag, xs = coord(p.X-x, 0x02, 0x10, xs)
+			yFlag, ys = coord(p.Y-y, 0x04, 0x20, ys)
+			flags = append(flags, flag|xFlag|yFlag)
+			x, y = p.X, p.Y
+		}
+	}
+	w.Write(flags)
+	w.Write(xs)
+	w.Write(ys)
+	w.pad4()
+	return w.Bytes(), nil
+}
+
+// tables returns the tables of the font, sorted by tag. Glyph 0 is the empty
+// .notdef glyph and the other glyphs follow in order.
+func (f *sfntFont) tables() ([]sfntTable, error) {
+	numGlyphs := len(f.Glyphs) + 1
+	if numGlyphs > math.MaxUint16 {
+		return nil, fmt.Errorf("too many glyphs (%d)", numGlyphs)
+	}
+	advance := f.UnitsPerEm
+
+	var glyf, loca, hmtx fontWriter
+	bounds := glyphBounds{math.MaxInt, math.MaxInt, math.MinInt, math.MinInt}
+	maxPoints, maxContours := 0, 0
+	minLsb, minRsb, maxExtent := 0, 0, 0
+	// empty .notdef glyph
+	hmtx.u16(advance)
+	hmtx.u16(0)
+	loca.u32(0)
+	loca.u32(0)
+	for _, g := range f.Glyphs {
+		data, err := encodeGlyph(g.Contours)
+		if err != nil {
+			return nil, fmt.Errorf("glyph U+%04X: %w", g.Codepoint, err)
+		}
+		glyf.Write(data)
+		loca.u32(glyf.Len())
+		lsb := 0
+		if len(g.Contours) > 0 {
+			b := contoursBounds(g.Contours)
+			bounds = glyphBounds{min(bounds.xMin, b.xMin), min(bounds.yMin, b.yMin), max(bounds.xMax, b.xMax), max(bounds.yMax, b.yMax)}
+			lsb = b.xMin
+			minLsb, minRsb, maxExtent = min(minLsb, b.xMin), min(minRsb, advance-b.xMax), max(maxExtent, b.xMax)
+			points := 0
+			for _, c := range g.Contours {
+				points += len(c)
+			}
+			maxPoints, maxContours = max(maxPoints, points), max(maxContours, len(g.Contours))
+		}
+		hmtx.u16(advance)
+		hmtx.u16(lsb)
+	}
+
+	if bounds.xMin > bounds.xMax {
+		bounds = glyphBounds{}
+	}
+
+	var head fontWriter
+	head.u32(0x00010000)
+	head.u32(0x00010000) // font revision
+	head.u32(0)          // checksum adjustment, see writeTTF
+	head.u32(0x5F0F3CF5)
+	head.u16(0x000B) // baseline and lsb at 0, integer scaling
+	head.u16(f.UnitsPerEm)
+	head.Write(make([]byte, 16)) // created and modified, left out for reproducible builds
+	for _, v := range []int{bounds.xMin, bounds.yMin, bounds.xMax, bounds.yMax} {
+		head.u16(v)
+	}
+	head.u16(0) // mac style
+	head.u16(8) // lowest readable size
+	head.u16(2) // font direction hint
+	head.u16(1) // long loca offsets
+	head.u16(0)
+
+	var hhea fontWriter
+	hhea.u32(0x00010000)
+	hhea.u16(f.Ascent)
+	hhea.u16(f.Descent)
+	hhea.u16(0) // line gap
+	hhea.u16(advance)
+	hhea.u16(minLsb)
+	hhea.u16(minRsb)
+	hhea.u16(maxExtent)
+	hhea.u16(1) // caret slope rise
+	hhea.u16(0) // caret slope run
+	hhea.Write(make([]byte, 12))
+	hhea.u16(numGlyphs)
+
+	var maxp fontWriter
+	maxp.u32(0x00010000)
+	maxp.u16(numGlyphs)
+	maxp.u16(maxPoints)
+	maxp.u16(maxContours)
+	maxp.u16(0) // composite points
+	maxp.u16(0) // composite contours
+	maxp.u16(2) // zones
+	maxp.Write(make([]byte, 16))
+
+	first, last := 0xFFFF, 0
+	for _, g := range f.Glyphs {
+		first, last = min(first, int(g.Codepoint)), max(last, int(g.Codepoint))
+	}
+	if len(f.Glyphs) == 0 {
+		first = 0
+	}
+	var os2 fontWriter
+	os2.u16(4)
+	os2.u16(advance)
+	os2.u16(400) // regular weight
+	os2.u16(5)   // normal width
+	os2.u16(0)   // installable embedding
+	for _, v := range []int{650, 600, 0, 75, 650, 600, 0, 350, 50, 300} {
+		// subscript, superscript and strikeout metrics
+		os2.u16(v)
+	}
+	os2.u16(0)                  // family class
+	os2.Write(make([]byte, 10)) // panose
+	os2.u32(0)                  // unicode ranges
+	os2.u32(1 << (60 - 32))     // private use area
+	os2.u32(0)
+	os2.u32(0)
+	os2.WriteString("    ") // vendor
+	os2.u16(0x40)           // regular
+	os2.u16(first)
+	os2.u16(last)
+	os2.u16(f.Ascent)
+	os2.u16(f.Descent)
+	os2.u16(0)
+	os2.u16(max(f.Ascent, bounds.yMax))
+	os2.u16(max(-f.Descent, -bounds.yMin))
+	os2.u32(1) // latin 1 code page
+	os2.u32(0)
+	os2.u16(0) // x height
+	os2.u16(0) // cap height
+	os2.u16(0) // default char
+	os2.u16(0x20)
+	os2.u16(0) // max context
+
+	cmap, err := f.cmap()
+	if err != nil {
+		return nil, err
+	}
+
+	var post fontWriter
+	post.u32(0x00030000) // no glyph names
+	post.Write(make([]byte, 28))
+
+	tables := []sfntTable{
+		{"OS/2", os2.Bytes()},
+		{"cmap", cmap},
+		{"glyf", glyf.Bytes()},
+		{"head", head.Bytes()},
+		{"hhea", hhea.Bytes()},
+		{"hmtx", hmtx.Bytes()},
+		{"loca", loca.Bytes()},
+		{"maxp", maxp.Bytes()},
+		{"name", f.name()},
+		{"post", post.Bytes()},
+	}
+	return tables, nil
+}
+
+// cmap maps the codepoints to the glyphs with a format 4 subtable, which
+// covers the private use area of the BMP.
+func (f *sfntFont) cmap() ([]byte, error) {
+	type cmapSegment struct {
+		start, end, delta int
+	}
+	segments := []cmapSegment{}
+	for i, g := range f.Glyphs {
+		cp, id := int(g.Codepoint), i+1
+		if cp > 0xFFFF {
+			return nil, fmt.Errorf("codepoint U+%04X is outside of the BMP", cp)
+		}
+		if n := len(segments); n > 0 && cp <= segments[n-1].end {
+			return nil, fmt.Errorf("codepoints are not sorted (U+%04X)", cp)
+		}
+		if n := len(segments); n > 0 && segments[n-1].end == cp-1 && segments[n-1].delta == id-cp {
+			segments[n-1].end = cp
+			continue
+		}
+		segments = append(segments, cmapSegment{cp, cp, id - cp})
+	}
+	segments = append(segments, cmapSegment{0xFFFF, 0xFFFF, 1})
+
+	segCount := len(segments)
+	searchRange := 2 << (bits.Len(uint(segCount)) - 1)
+	var sub fontWriter
+	sub.u16(4)
+	sub.u16(16 + 8*segCount) // length
+	sub.u16(0)               // language
+	sub.u16(2 * segCount)
+	sub.u16(searchRange)
+	sub.u16(bits.Len(uint(segCount)) - 1)
+	sub.u16(2*segCount - searchRange)
+	for _, s := range segments {
+		sub.u16(s.end)
+	}
+	sub.u16(0)
+	for _, s := range segments {
+		sub.u16(s.start)
+	}
+	for _, s := range segments {
+		sub.u16(s.delta)
+	}
+	for range segments {
+		sub.u16(0) // range offset
+	}
+
+	var w fontWriter
+	w.u16(0)
+	w.u16(2)
+	// unicode BMP and windows unicode BMP share the subtable
+	for _, encoding := range [][2]int{{0, 3}, {3, 1}} {
+		w.u16(encoding[0])
+		w.u16(encoding[1])
+		w.u32(4 + 8*2)
+	}
+	w.Write(sub.Bytes())
+	return w.Bytes(), nil
+}
+
+// name holds the names of the font for windows (the other platforms use
+// them too).
+func (f *sfntFont) name() []byte {
+	postscriptName := []rune{}
+	for _, r := range f.FamilyName {
+		if r > ' ' && r < 0x7F && !slices.Contains([]rune("[](){}<>/%"), r) {
+			postscriptName = append(postscriptName, r)
+		}
+	}
+	names := []string{
+		1: f.FamilyName,
+		2: "Regular",
+		3: f.FamilyName + " " + f.Version,
+		4: f.FamilyName,
+		5: "Version " + f.Version,
+		6: string(postscriptName),
+	}
+	var records, strs fontWriter
+	for id, name := range names[1:] {
+		encoded := []byte{}
+		for _, u := range utf16.Encode([]rune(name)) {
+			encoded = binary.BigEndian.AppendUint16(encoded, u)
+		}
+		records.u16(3)      // windows
+		records.u16(1)      // unicode BMP
+		records.u16(0x0409) // en-US
+		records.u16(id + 1)
+		records.u16(len(encoded))
+		records.u16(strs.Len())
+		strs.Write(encoded)
+	}
+	var w fontWriter
+	w.u16(0)
+	w.u16(len(names) - 1)
+	w.u16(6 + records.Len())
+	w.Write(records.Bytes())
+	w.Write(strs.Bytes())
+	return w.Bytes()
+}
+
+func tableChecksum(data []byte) uint32 {
+	sum := uint32(0)
+	for i := 0; i < len(data); i += 4 {
+		word := make([]byte, 4)
+		copy(word, data[i:])
+		sum += binary.BigEndian.Uint32(word)
+	}
+	return sum
+}
+
+// writeTTF writes the tables as a TrueType font file. The checksum
+// adjustment of the head table is updated in place.
+func writeTTF(tables []sfntTable) []byte {
+	numTables := len(tables)
+	entrySelector := bits.Len(uint(numTables)) - 1
+	searchRange := 16 << entrySelector
+	var w fontWriter
+	w.u32(0x00010000)
+	w.u16(numTables)
+	w.u16(searchRange)
+	w.u16(entrySelector)
+	w.u16(16*numTables - searchRange)
+	offset := 12 + 16*numTables
+	var head []byte
+	headOffset := 0
+	for _, t := range tables {
+		w.WriteString(t.Tag)
+		w.u32(int(tableChecksum(t.Data)))
+		w.u32(offset)
+		w.u32(len(t.Data))
+		if t.Tag == "head" {
+			head, headOffset = t.Data, offset
+		}
+		offset += (len(t.Data) + 3) &^ 3
+	}
+	for _, t := range tables {
+		w.Write(t.Data)
+		w.pad4()
+	}
+	font := w.Bytes()
+	if head != nil {
+		adjustment := 0xB1B0AFBA - tableChecksum(font)
+		binary.BigEndian.PutUint32(head[8:], adjustment)
+		binary.BigEndian.PutUint32(font[headOffset+8:], adjustment)
+	}
+	return font
+}
+
+// Indexes of the known table tags of WOFF2
+var woff2KnownTags = map[string]int{
+	"cmap": 0, "head": 1, "hhea": 2, "hmtx": 3, "maxp": 4, "name": 5, "OS/2": 6, "post": 7,
+	"cvt ": 8, "fpgm": 9, "glyf": 10, "loca": 11, "prep": 12,
+}
+
+// writeWOFF2 compresses the tables (as written by writeTTF) into a WOFF2
+// file. The tables are not transformed: glyf and loca use the null
+// transform, which keeps the encoder simple at the cost of a larger file.
+func writeWOFF2(tables []sfntTable, sfntSize int) ([]byte, error) {
+	// loca must follow glyf
+	ordered := []sfntTable{}
+	for _, t := range tables {
+		if t.Tag == "loca" {
+			continue
+		}
+		ordered = append(ordered, t)
+		if t.Tag == "glyf" {
+			for _, loca := range tables {
+				if loca.Tag == "loca" {
+					ordered = append(ordered, loca)
+				}
+			}
+		}
+	}
+
+	var directory, stream fontWriter
+	for _, t := range ordered {
+		flags, known := woff2KnownTags[t.Tag]
+		if !known {
+			flags = 63
+		}
+		if t.Tag == "glyf" || t.Tag == "loca" {
+			flags |= 3 << 6 // null transform
+		}
+		directory.u8(flags)
+		if !known {
+			directory.WriteString(t.Tag)
+		}
+		directory.Write(uintBase128(len(t.Data)))
+		stream.Write(t.Data)
+	}
+	var compressed bytes.Buffer
+	bw := brotli.NewWriterLevel(&compressed, brotli.BestCompression)
+	if _, err := bw.Write(stream.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := bw.Close(); err != nil {
+		return nil, err
+	}
+
+	var w fontWriter
+	length := (48 + directory.Len() + compressed.Len() + 3) &^ 3
+	w.WriteString("wOF2")
+	w.u32(0x00010000)
+	w.u32(length)
+	w.u16(len(ordered))
+	w.u16(0)
+	w.u32(sfntSize)
+	w.u32(compressed.Len())
+	w.u16(1) // version
+	w.u16(0)
+	w.Write(make([]byte, 20)) // no metadata or private data
+	w.Write(directory.Bytes())
+	w.Write(compressed.Bytes())
+	w.pad4()
+	return w.Bytes(), nil
+}
+
+// uintBase128 encodes a value with 7 bits per byte, most significant first.
+func uintBase128(v int) []byte {
+	out := []byte{byte(v & 0x7F)}
+	for v >>= 7; v > 0; v >>= 7 {
+		out = append([]byte{byte(v&0x7F) | 0x80}, out...)
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package lucidegen
+
+import (
+	"fmt"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+
+	common "github.com/bryanvaz/go-lucide/src/common"
+)
+
+// Stylesheet written next to the rollup file
+const STYLESHEET_FILE = "icons.css"
+
+const stylesheetTemplate = `/*
+ * Custom properties theming the icons, e.g. :root { --lucide-size: 20px; }
+ *   --lucide-size          width and height of the icons (24px)
+ *   --lucide-stroke        color of the icons (currentColor)
+ *   --lucide-stroke-width  stroke width of the svg icons (2)
+ * The css masks below always use them, the svg icons when rendered with
+ * SetCSSVars(true) through the rules below. The size, color and stroke width
+ * passed to an svg icon, and classes setting them, override them.
+ */
+{{ .ThemeCSS }}
+
+/* {{ .SetName }} icons as css masks, drawn with the color of the text */
+[class^="{{ .ClassPrefix }}"],
+[class*=" {{ .ClassPrefix }}"] {
+  display: inline-block;
+  width: var(--lucide-size, 24px);
+  height: var(--lucide-size, 24px);
+  background-color: var(--lucide-stroke, currentColor);
+  -webkit-mask-repeat: no-repeat;
+  mask-repeat: no-repeat;
+  -webkit-mask-position: center;
+  mask-position: center;
+  -webkit-mask-size: 100% 100%;
+  mask-size: 100% 100%;
+}
+{{ range .Rules }}
+{{ .Selectors }} {
+  -webkit-mask-image: url("{{ .URI }}");
+  mask-image: url("{{ .URI }}");
+}
+{{- end }}
+`
+
+type stylesheetRule struct {
+	Selectors string
+	URI       string
+}
+
+type stylesheetTemplateParams struct {
+	SetName     string
+	ThemeCSS    string
+	ClassPrefix string
+	Rules       []stylesheetRule
+}
+
+// runtimeNodes converts the svg to the types of the runtime helpers, with the
+// root attributes the generated package uses for the icon.
+func runtimeNodes(svg *Svg) (common.Node, common.IconNode) {
+	rootAttrs := svg.RootAttrs
+	if svg.HasLucideRootAttrs() {
+		rootAttrs = lucideRootAttrs
+	}
+	root := common.Node{Tag: "svg"}
+	for _, attr := range rootAttrs {
+		if attr.Name != "class" {
+			root.Attrs = append(root.Attrs, common.Attr{Name: attr.Name, Value: attr.Value})
+		}
+	}
+	node := common.IconNode{}
+	for _, el := range svg.Elements {
+		n := common.Node{Tag: el.Tag}
+		for _, attr := range el.Attrs {
+			n.Attrs = append(n.Attrs, common.Attr{Name: attr.Name, Value: attr.Value})
+		}
+		node = append(node, n)
+	}
+	return root, node
+}
+
+// createStylesheet creates a stylesheet with a class per icon and alias
+// (e.g. .lucide-icon-house), masking the background with the icon. The data
+// URIs are the ones returned by DataURI with the default options.
+func createStylesheet(icons []*LucideIconSvg, setName string) (string, error) {
+	prefix := setFileName(setName) + "-icon-"
+	params := stylesheetTemplateParams{SetName: setName, ThemeCSS: common.ThemeCSS, ClassPrefix: prefix}
+	for i, names := range iconNames(icons) {
+		svg, err := icons[i].Svg()
+		if err != nil {
+			return "", err
+		}
+		selectors := []string{}
+		for _, name := range names {
+			selectors = append(selectors, "."+prefix+name)
+		}
+		root, node := runtimeNodes(svg)
+		params.Rules = append(params.Rules, stylesheetRule{
+			Selectors: strings.Join(selectors, ",\n"),
+			URI:       common.DataURISvg(root, node, common.DataURIOptions{}),
+		})
+	}
+	css, err := executeTemplate(stylesheetTemplate, params)
+	if err != nil {
+		return "", err
+	}
+	return string(css), nil
+}
+
+// writeStylesheet writes the css mask stylesheet of the icons into path.
+func writeStylesheet(path string, icons []*LucideIconSvg, setName string) error {
+	css, err := createStylesheet(icons, setName)
+	if err != nil {
+		return fmt.Errorf("error creating stylesheet: %w", err)
+	}
+	cssPath := filepathPkg.Join(path, STYLESHEET_FILE)
+	if err := os.WriteFile(cssPath, []byte(css), 0644); err != nil {
+		return fmt.Errorf("error writing to stylesheet: %w", err)
+	}
+	fmt.Println("Stylesheet saved to", cssPath)
+	return nil
+}
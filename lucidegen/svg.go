@@ -0,0 +1,319 @@
+package lucidegen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Child elements that can be used in an icon
+var supportedSvgElements = map[string]bool{
+	"path":     true,
+	"circle":   true,
+	"rect":     true,
+	"line":     true,
+	"polyline": true,
+	"polygon":  true,
+	"ellipse":  true,
+}
+
+// Root attributes of the Lucide icons, any icon with exactly these root
+// attributes uses the shared defaults of the runtime helpers.
+var lucideRootAttrs = []SvgAttr{
+	{"xmlns", "http://www.w3.org/2000/svg"},
+	{"width", "24"},
+	{"height", "24"},
+	{"viewBox", "0 0 24 24"},
+	{"fill", "none"},
+	{"stroke", "currentColor"},
+	{"stroke-width", "2"},
+	{"stroke-linecap", "round"},
+	{"stroke-linejoin", "round"},
+}
+
+// SvgAttr is an attribute of an svg element.
+type SvgAttr struct {
+	Name  string
+	Value string
+}
+
+// SvgElement is a child element (shape) of an icon.
+type SvgElement struct {
+	Tag string
+	// Attributes in the order of the svg file
+	Attrs []SvgAttr
+}
+
+// Svg is the parsed content of an icon's svg file.
+type Svg struct {
+	// Attributes of the root svg element in the order of the svg file
+	RootAttrs []SvgAttr
+	Elements  []SvgElement
+}
+
+// Attr returns the value of the root attribute with the given name.
+func (s *Svg) Attr(name string) (string, bool) {
+	for _, attr := range s.RootAttrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// HasLucideRootAttrs returns true if the root attributes are the standard
+// Lucide ones, in the same order, so that the opening tag rendered with and
+// without attributes lists them in the same order.
+func (s *Svg) HasLucideRootAttrs() bool {
+	return slices.Equal(s.RootAttrs, lucideRootAttrs)
+}
+
+// Markup renders the child elements as svg markup, one element per line.
+func (s *Svg) Markup() string {
+	lines := []string{}
+	for _, el := range s.Elements {
+		lines = append(lines, el.Markup())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Document renders the svg as a standalone svg file, with the root
+// attributes and one child element per line.
+func (s *Svg) Document() string {
+	var b strings.Builder
+	b.WriteString("<svg")
+	for _, attr := range s.RootAttrs {
+		b.WriteString("\n  " + attr.Name + `="` + html.EscapeString(attr.Value) + `"`)
+	}
+	b.WriteString("\n>\n")
+	for _, el := range s.Elements {
+		b.WriteString("  " + el.Markup() + "\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// Get returns the value of the attribute with the given name.
+func (e SvgElement) Get(name string) (string, bool) {
+	for _, attr := range e.Attrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// Markup renders the element as a self closing svg tag.
+func (e SvgElement) Markup() string {
+	var b strings.Builder
+	b.WriteString("<" + e.Tag)
+	for _, attr := range e.Attrs {
+		b.WriteString(" " + attr.Name + `="` + html.EscapeString(attr.Value) + `"`)
+	}
+	b.WriteString(" />")
+	return b.String()
+}
+
+// SvgParseError is returned by ParseSvg for malformed or unsupported svgs.
+type SvgParseError struct {
+	Path   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SvgParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Msg)
+}
+
+// ParseSvg parses the svg of an icon. The svg must have a single root svg
+// element whose children are all supported shapes (path, circle, rect,
+// line, polyline, polygon and ellipse) without children of their own.
+// Comments, processing instructions and whitespace are ignored, anything else
+// (text, groups, styles, namespaced attributes, ...) is rejected with the
+// position of the offending construct. The path is only used in errors.
+func ParseSvg(path string, content string) (*Svg, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = true
+	line, column := 1, 1
+	fail := func(format string, args ...any) error {
+		return &SvgParseError{Path: path, Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	var svg *Svg
+	// names of the open elements
+	open := []string{}
+	closed := false
+	for {
+		// errors point at the start of the offending token
+		line, column = decoder.InputPos()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fail("invalid xml: %s", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := rawName(t.Name)
+			depth := len(open)
+			switch {
+			case depth == 0 && closed:
+				return nil, fail("unexpected element <%s> after the root svg element", name)
+			case depth == 0 && name != "svg":
+				return nil, fail("root element must be <svg>, found <%s>", name)
+			case depth == 0:
+				attrs, err := parseSvgAttrs(t.Attr, true)
+				if err != nil {
+					return nil, fail("<svg>: %s", err)
+				}
+				svg = &Svg{RootAttrs: attrs}
+			case depth == 1 && !supportedSvgElements[name]:
+				return nil, fail("unsupported element <%s>", name)
+			case depth == 1:
+				attrs, err := parseSvgAttrs(t.Attr, false)
+				if err != nil {
+					return nil, fail("<%s>: %s", name, err)
+				}
+				svg.Elements = append(svg.Elements, SvgElement{Tag: name, Attrs: attrs})
+			default:
+				return nil, fail("unsupported nested element <%s>", name)
+			}
+			open = append(open, name)
+		case xml.EndElement:
+			name := rawName(t.Name)
+			if len(open) == 0 || open[len(open)-1] != name {
+				return nil, fail("unexpected closing tag </%s>", name)
+			}
+			open = open[:len(open)-1]
+			closed = len(open) == 0
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				return nil, fail("unsupported text content %q", strings.TrimSpace(string(t)))
+			}
+		case xml.Directive:
+			return nil, fail("unsupported directive <!%s>", string(t))
+		case xml.Comment, xml.ProcInst:
+		}
+	}
+	if svg == nil {
+		return nil, &SvgParseError{Path: path, Line: 1, Column: 1, Msg: "no <svg> element"}
+	}
+	if !closed {
+		return nil, fail("unclosed <svg> element")
+	}
+	return svg, nil
+}
+
+func rawName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+func parseSvgAttrs(xmlAttrs []xml.Attr, root bool) ([]SvgAttr, error) {
+	attrs := []SvgAttr{}
+	seen := map[string]bool{}
+	for _, xmlAttr := range xmlAttrs {
+		name := rawName(xmlAttr.Name)
+		if root && xmlAttr.Name.Space == "xmlns" {
+			// namespace declarations, only useful to namespaced attributes
+			// which are rejected anyway
+			continue
+		}
+		if xmlAttr.Name.Space != "" || (name == "xmlns" && !root) {
+			return nil, fmt.Errorf("unsupported attribute %s", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate attribute %s", name)
+		}
+		seen[name] = true
+		attrs = append(attrs, SvgAttr{Name: name, Value: xmlAttr.Value})
+	}
+	return attrs, nil
+}
+
+// rootAttrsCode returns the Go expression of the root attributes of the
+// svgIcon of an icon. Icons with the standard Lucide root
+// attributes share the runtime defaults, the others get a declaration of
+// their own.
+func rootAttrsCode(icon *LucideIconSvg, svg *Svg) (expr string, decl string) {
+	if svg.HasLucideRootAttrs() {
+		return "defaultRootAttrs", ""
+	}
+	expr = lowerFirst(icon.CamelCaseName()) + "RootAttrs"
+	lines := []string{"var " + expr + " = rootAttrs{"}
+	for _, attr := range svg.RootAttrs {
+		if attr.Name == "class" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("\t{%q, %q},", attr.Name, attr.Value))
+	}
+	lines = append(lines, "}")
+	return expr, strings.Join(lines, "\n")
+}
+
+// svgIconCode returns the name and declaration of the svgIcon rendering the
+// root svg element of an icon, along with the declaration of its root
+// attributes. content is the Go expression of the markup of its shapes, if
+// the flavor renders the whole element.
+func svgIconCode(icon *LucideIconSvg, svg *Svg, content string) (name string, decl string) {
+	rootAttrs, rootAttrsDecl := rootAttrsCode(icon, svg)
+	classes := strings.Fields(iconClasses(icon, svg))
+	slices.Sort(classes)
+	classes = slices.Compact(classes)
+	class := strings.Join(classes, " ")
+
+	name = lowerFirst(icon.CamelCaseName()) + "Svg"
+	fields := []string{"root: " + rootAttrs, fmt.Sprintf("class: %q", class), fmt.Sprintf("open: %q", svgOpenTag(svg, class))}
+	if content != "" {
+		fields = append(fields, "content: "+content)
+	}
+	lines := []string{}
+	if rootAttrsDecl != "" {
+		lines = append(lines, rootAttrsDecl, "")
+	}
+	lines = append(lines,
+		fmt.Sprintf("// Root svg element of the %s icon", icon.KebabName()),
+		fmt.Sprintf("var %s = &svgIcon{%s}", name, strings.Join(fields, ", ")),
+	)
+	return name, strings.Join(lines, "\n")
+}
+
+// svgOpenTag renders the opening svg tag of an icon with its default
+// attributes, the way the runtime does when attributes are passed to it: in
+// the order of the svg file and followed by the class.
+func svgOpenTag(svg *Svg, class string) string {
+	var b strings.Builder
+	b.WriteString("<svg")
+	for _, attr := range svg.RootAttrs {
+		if attr.Name != "class" {
+			b.WriteString(" " + html.EscapeString(attr.Name) + `="` + html.EscapeString(attr.Value) + `"`)
+		}
+	}
+	b.WriteString(` class="` + html.EscapeString(class) + `">`)
+	return b.String()
+}
+
+// iconClasses returns the classes of the icon, including the ones of the root
+// svg element.
+func iconClasses(icon *LucideIconSvg, svg *Svg) string {
+	classes := icon.LucideClasses()
+	if class, ok := svg.Attr("class"); ok {
+		for _, cl := range strings.Fields(class) {
+			if !slices.Contains(strings.Fields(classes), cl) {
+				classes += " " + cl
+			}
+		}
+	}
+	return classes
+}
+
+func lowerFirst(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,35 @@
+package lucidegen
+
+import (
+	"fmt"
+	"os"
+	filepathPkg "path/filepath"
+)
+
+// Directory of the root package holding the svg files embedded as FS
+const svgFilesDir = "svg"
+
+// writeSvgFiles writes the svg file of every icon and alias (e.g. house.svg
+// and its alias home.svg) into the svg directory of path, for the FS of the
+// rollup. The directory is only written by the generator, so svg files left
+// over from a previous generation are removed with it.
+func writeSvgFiles(path string, icons []*LucideIconSvg) error {
+	path = filepathPkg.Join(path, svgFilesDir)
+	if err := resetDir(path); err != nil {
+		return err
+	}
+	for i, names := range iconNames(icons) {
+		svg, err := icons[i].Svg()
+		if err != nil {
+			return err
+		}
+		content := []byte(svg.Document())
+		for _, name := range names {
+			if err := os.WriteFile(filepathPkg.Join(path, name+".svg"), content, 0644); err != nil {
+				return fmt.Errorf("error writing svg file: %w", err)
+			}
+		}
+	}
+	fmt.Println("Svg files saved to", path)
+	return nil
+}
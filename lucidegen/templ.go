@@ -0,0 +1,351 @@
+package lucidegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/a-h/templ/cmd/templ/imports"
+	"github.com/a-h/templ/generator"
+	parser "github.com/a-h/templ/parser/v2"
+)
+
+const templateTemplFunc = `
+{{ .SvgDecl }}
+
+// Renders the {{ .SetName }} icon {{ .KebabCaseName }}.
+templ {{ .FuncName }}(attrs ...templ.Attributes) {
+    @svgOpen({{ .SvgName }}, attrs)
+    @svgContent({{ .SvgName }}, attrs)
+    { children... }
+    @svgClose()
+}
+
+const {{ .ContentName }} = {{ printf "%q" .Content }}
+
+{{ .NodeDecl }}
+`
+const templateTemplFile = `package icons
+
+{{ .Funcs }}
+`
+
+// The element file renders the svg tags of the icons with templ's class
+// types, and the stylesheet of the animations.
+const templElementFile = `package icons
+
+import (
+	"context"
+	"io"
+
+	"github.com/a-h/templ"
+)
+
+// svgOpenTag renders the opening svg tag of an icon as a templ component, see
+// svgOpen.
+type svgOpenTag struct {
+	icon  *svgIcon
+	attrs []templ.Attributes
+}
+
+// svgOpen returns the opening svg tag of an icon rendered with the attributes
+// passed to it, for templ components writing the shapes themselves.
+func svgOpen(icon *svgIcon, attrs []templ.Attributes) svgOpenTag {
+	return svgOpenTag{icon: icon, attrs: attrs}
+}
+
+func (t svgOpenTag) Render(ctx context.Context, w io.Writer) error {
+	return writeTemplSvgOpen(ctx, w, t.icon, t.attrs)
+}
+
+// svgContentTag renders the shapes of an icon as a templ component, see
+// svgContent.
+type svgContentTag struct {
+	icon  *svgIcon
+	attrs []templ.Attributes
+}
+
+// svgContent returns the shapes of an icon rendered with the options passed to
+// it, see svgOpen.
+func svgContent(icon *svgIcon, attrs []templ.Attributes) svgContentTag {
+	return svgContentTag{icon: icon, attrs: attrs}
+}
+
+func (t svgContentTag) Render(ctx context.Context, w io.Writer) error {
+	return writeTemplSvgContent(ctx, w, t.icon, t.attrs)
+}
+
+// svgCloseTag renders the closing svg tag of an icon as a templ component.
+type svgCloseTag struct{}
+
+// svgClose returns the closing svg tag of an icon, see svgOpen.
+func svgClose() svgCloseTag {
+	return svgCloseTag{}
+}
+
+func (svgCloseTag) Render(ctx context.Context, w io.Writer) error {
+	_, err := io.WriteString(w, "</svg>")
+	return err
+}
+
+// stylesHandle renders the stylesheet of the icons once per page.
+var stylesHandle = templ.NewOnceHandle(templ.WithComponent(templ.ComponentFunc(renderStyles)))
+
+// Styles renders the stylesheet of the icon animations and css custom
+// properties (see AnimationCSS and ThemeCSS) once per page, with the nonce of
+// the context (see templ.WithNonce) for content security policies.
+func Styles() templ.Component {
+	return stylesHandle.Once()
+}
+
+func renderStyles(ctx context.Context, w io.Writer) error {
+	if _, err := io.WriteString(w, "<style"); err != nil {
+		return err
+	}
+	if nonce := templ.GetNonce(ctx); nonce != "" {
+		if _, err := io.WriteString(w, " nonce=\""+templ.EscapeString(nonce)+"\""); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, ">"+AnimationCSS+ThemeCSS+"</style>")
+	return err
+}
+
+// writeTemplSvgOpen writes the opening svg tag of an icon like writeSvgOpen,
+// with templ's class types and the size scale of the context. The css of the
+// component classes passed to the icon (templ css blocks) is rendered first,
+// like templ does for elements.
+func writeTemplSvgOpen(ctx context.Context, w io.Writer, icon *svgIcon, attrs []templ.Attributes) error {
+	if usesDefaultOpen(attrs) {
+		_, err := io.WriteString(w, icon.open)
+		return err
+	}
+	for _, attr := range attrs {
+		value, ok := attr["class"]
+		if !ok {
+			value = attr["className"]
+		}
+		switch value.(type) {
+		case nil, string:
+		default:
+			if err := templ.RenderCSSItems(ctx, w, value); err != nil {
+				return err
+			}
+		}
+	}
+	return writeSvgOpen(w, icon, attrs, templClassNames, templValue, sizeScaleFrom(ctx))
+}
+
+// templValue converts templ's conditional attribute values the way
+// templ.RenderAttributes does: templ.KV("page", active) is written as a string
+// and templ.KV(true, cond) as a boolean, when their condition is true.
+func templValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case templ.KeyValue[string, bool]:
+		if !v.Value {
+			return nil, true
+		}
+		return v.Key, true
+	case templ.KeyValue[bool, bool]:
+		return v.Key && v.Value, true
+	}
+	return nil, false
+}
+
+// writeTemplSvgContent writes the shapes of an icon like writeSvgContent, with
+// the size scale of the context.
+func writeTemplSvgContent(ctx context.Context, w io.Writer, icon *svgIcon, attrs []templ.Attributes) error {
+	if noAttrs(attrs) {
+		_, err := io.WriteString(w, icon.content)
+		return err
+	}
+	return writeSvgContent(w, icon, attrs, sizeScaleFrom(ctx))
+}
+
+// templClassNames appends the classes of the value of a class attribute like
+// appendClassNames, along with templ's class types: templ.Classes, templ.KV
+// conditional classes and templ.CSSClass values.
+func templClassNames(list []className, value any) ([]className, bool) {
+	switch v := value.(type) {
+	case templ.CSSClasses:
+		for _, item := range v {
+			var ok bool
+			if list, ok = templClassNames(list, item); !ok {
+				return list, false
+			}
+		}
+		return list, true
+	case []templ.CSSClass:
+		for _, c := range v {
+			list = appendClassList(list, c.ClassName(), true)
+		}
+		return list, true
+	case func() templ.CSSClass:
+		return appendClassList(list, v().ClassName(), true), true
+	case templ.KeyValue[string, bool]:
+		return appendClassList(list, v.Key, v.Value), true
+	case []templ.KeyValue[string, bool]:
+		for _, kv := range v {
+			list = appendClassList(list, kv.Key, kv.Value)
+		}
+		return list, true
+	case templ.KeyValue[templ.CSSClass, bool]:
+		return appendClassList(list, v.Key.ClassName(), v.Value), true
+	case []templ.KeyValue[templ.CSSClass, bool]:
+		for _, kv := range v {
+			list = appendClassList(list, kv.Key.ClassName(), kv.Value)
+		}
+		return list, true
+	}
+	return appendClassNames(list, value)
+}
+`
+
+type templFileTemplateParams struct {
+	Funcs string
+}
+
+var tmplTemplFuncGen *template.Template
+var tmplTemplFileGen *template.Template
+
+func generateTemplFunc(icon *LucideIconSvg, setName string) (string, error) {
+	var err error
+	if tmplTemplFuncGen == nil {
+		tmplTemplFuncGen, err = template.New("templTemplate").Parse(templateTemplFunc)
+		if err != nil {
+			return "", err
+		}
+	}
+	output, err := executeIconTemplate(tmplTemplFuncGen, icon, setName, templMarkup)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+func generateTemplFile(funcs ...string) (string, error) {
+	var err error
+	if tmplTemplFileGen == nil {
+		tmplTemplFileGen, err = template.New("templFile").Parse(templateTemplFile)
+		if err != nil {
+			return "", err
+		}
+	}
+	templFileData := templFileTemplateParams{
+		Funcs: strings.Join(funcs, "\n"),
+	}
+
+	var outputFileBuffer bytes.Buffer
+	if err := tmplTemplFileGen.Execute(&outputFileBuffer, templFileData); err != nil {
+		return "", err
+	}
+
+	outputTemplString := outputFileBuffer.String()
+	formattedTemplString, err := formatTemplFile(outputTemplString)
+	if err != nil {
+		return "", err
+	}
+	return formattedTemplString, nil
+}
+
+func formatTemplFile(templFile string) (string, error) {
+	t, err := parser.ParseString(templFile)
+	if err != nil {
+		return "", err
+	}
+	t.Filepath = "placeholder.templ"
+	t, err = imports.Process(t)
+	if err != nil {
+		return "", err
+	}
+	w := new(bytes.Buffer)
+	if err = t.Write(w); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func generateGoFromTempl(templFile string) (string, error) {
+	t, err := parser.ParseString(templFile)
+	if err != nil {
+		return "", err
+	}
+	b := new(bytes.Buffer)
+	_, err = generator.Generate(t, b)
+	if err != nil {
+		return "", err
+	}
+	formattedGoCode, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return string(formattedGoCode), nil
+}
+
+var templRollupSignature = rollupSignature{
+	Imports: []string{`"context"`, `"github.com/a-h/templ"`},
+	Params:  "attrs ...templ.Attributes",
+	Args:    "attrs...",
+	Result:  "templ.Component",
+	Funcs: `// Styles renders the stylesheet of the icon animations and css custom
+// properties (see AnimationCSS and ThemeCSS) once per page, with the nonce of
+// the context (see templ.WithNonce) for content security policies.
+func Styles() templ.Component {
+	return iconFuncs.Styles()
+}
+
+// WithSizeScale returns a context rendering the icons with the size scale,
+// overriding the one set by SetSizeScale.
+func WithSizeScale(ctx context.Context, scale SizeScale) context.Context {
+	return iconFuncs.WithSizeScale(ctx, scale)
+}`,
+}
+
+// templGenerator writes the templ components of the icons into the icons
+// subpackage, along with the runtime helpers and the root rollup file.
+type templGenerator struct{}
+
+func (templGenerator) Name() string {
+	return "templ"
+}
+
+func (templGenerator) Generate(in GeneratorInput) error {
+	return generateComponents(in, componentFlavor{
+		name:      "templ",
+		require:   TEMPL_PACKAGE,
+		runtime:   []string{"common"},
+		signature: templRollupSignature,
+		files:     [][2]string{{"svg_templ.go", templElementFile}},
+		writeIcon: writeTemplIcon,
+	})
+}
+
+// writeTemplIcon writes the templ file of an icon and the go file generated
+// from it by templ.
+func writeTemplIcon(iconsPath string, icon *LucideIconSvg, setName string) error {
+	templFunc, err := generateTemplFunc(icon, setName)
+	if err != nil {
+		return fmt.Errorf("error generating templ func for %s: %w", icon.Basename(), err)
+	}
+	templFile, err := generateTemplFile(templFunc)
+	if err != nil {
+		return fmt.Errorf("error generating templ file for %s: %w", icon.Basename(), err)
+	}
+	goFile, err := generateGoFromTempl(templFile)
+	if err != nil {
+		return fmt.Errorf("error generating go file for %s: %w", icon.Basename(), err)
+	}
+	outputTemplPath := filepathPkg.Join(iconsPath, iconFileBase(icon)+".templ")
+	outputGoPath := filepathPkg.Join(iconsPath, iconFileBase(icon)+"_templ.go")
+	if err := os.WriteFile(outputTemplPath, []byte(templFile), 0644); err != nil {
+		return fmt.Errorf("error writing to output file %s: %w", outputTemplPath, err)
+	}
+	if err := os.WriteFile(outputGoPath, []byte(goFile), 0644); err != nil {
+		return fmt.Errorf("error writing to output file %s: %w", outputGoPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package lucidegen
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+	"text/template"
+)
+
+const templGoFileTemplate = `package icons
+
+import "github.com/a-h/templ"
+
+{{ .SvgDecl }}
+
+// Renders the {{ .SetName }} icon {{ .KebabCaseName }}.
+func {{ .FuncName }}(attrs ...templ.Attributes) templ.Component {
+	return svgComponent({{ .SvgName }}, attrs)
+}
+
+const {{ .ContentName }} = {{ printf "%q" .Content }}
+
+{{ .NodeDecl }}
+`
+
+// The component file renders the svg elements of the icons as templ
+// components.
+const templGoComponentFile = `package icons
+
+import (
+	"context"
+	"io"
+
+	"github.com/a-h/templ"
+	templruntime "github.com/a-h/templ/runtime"
+)
+
+// svgComponent returns the svg element of an icon rendered with the
+// attributes passed to it as a templ component, like the components generated
+// by templ. Without attributes, the icon itself is returned so that creating
+// the component doesn't allocate.
+func svgComponent(icon *svgIcon, attrs []templ.Attributes) templ.Component {
+	if noAttrs(attrs) {
+		return (*svgIconComponent)(icon)
+	}
+	return svgAttrsComponent{icon: icon, attrs: attrs}
+}
+
+// svgIconComponent renders an icon with the default attributes.
+type svgIconComponent svgIcon
+
+func (c *svgIconComponent) Render(ctx context.Context, w io.Writer) error {
+	return renderSvgComponent(ctx, w, (*svgIcon)(c), nil)
+}
+
+// svgAttrsComponent renders an icon with the attributes passed to it.
+type svgAttrsComponent struct {
+	icon  *svgIcon
+	attrs []templ.Attributes
+}
+
+func (c svgAttrsComponent) Render(ctx context.Context, w io.Writer) error {
+	return renderSvgComponent(ctx, w, c.icon, c.attrs)
+}
+
+// renderSvgComponent writes the svg element of an icon, with the children of
+// the component between its shapes and its closing tag.
+func renderSvgComponent(ctx context.Context, w io.Writer, icon *svgIcon, attrs []templ.Attributes) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	buf, isBuf := templruntime.GetBuffer(w)
+	if !isBuf {
+		defer func() {
+			if bufErr := templruntime.ReleaseBuffer(buf); err == nil {
+				err = bufErr
+			}
+		}()
+	}
+	ctx = templ.InitializeContext(ctx)
+	children := templ.GetChildren(ctx)
+	ctx = templ.ClearChildren(ctx)
+	if err := writeTemplSvgOpen(ctx, buf, icon, attrs); err != nil {
+		return err
+	}
+	if err := writeTemplSvgContent(ctx, buf, icon, attrs); err != nil {
+		return err
+	}
+	if err := children.Render(ctx, buf); err != nil {
+		return err
+	}
+	_, err = buf.WriteString("</svg>")
+	return err
+}
+`
+
+var tmplTemplGoFileGen *template.Template
+
+func generateTemplGoFile(icon *LucideIconSvg, setName string) (string, error) {
+	var err error
+	if tmplTemplGoFileGen == nil {
+		tmplTemplGoFileGen, err = template.New("templGoFile").Parse(templGoFileTemplate)
+		if err != nil {
+			return "", err
+		}
+	}
+	output, err := executeIconTemplate(tmplTemplGoFileGen, icon, setName, templMarkup)
+	if err != nil {
+		return "", err
+	}
+	formattedOutput, err := format.Source(output)
+	if err != nil {
+		return "", err
+	}
+	return string(formattedOutput), nil
+}
+
+// templMarkup renders the child elements the way the components generated by
+// templ do, so that both generators render the same html.
+func templMarkup(svg *Svg) string {
+	elements := []string{}
+	for _, el := range svg.Elements {
+		markup := strings.TrimSuffix(el.Markup(), " />")
+		elements = append(elements, markup+"></"+el.Tag+">")
+	}
+	return strings.Join(elements, " ")
+}
+
+// templGoGenerator writes the icons as plain Go functions returning templ
+// components, without running the templ generator. The package has the same
+// API as the one of the templ generator, with a file per icon rather than
+// two and without the per icon boilerplate of the templ generated code.
+type templGoGenerator struct{}
+
+func (templGoGenerator) Name() string {
+	return "templ-go"
+}
+
+func (templGoGenerator) Generate(in GeneratorInput) error {
+	return generateComponents(in, componentFlavor{
+		name:      "templ-go",
+		require:   TEMPL_PACKAGE,
+		runtime:   []string{"common"},
+		signature: templRollupSignature,
+		files:     [][2]string{{"svg_component.go", templGoComponentFile}, {"svg_templ.go", templElementFile}},
+		writeIcon: func(iconsPath string, icon *LucideIconSvg, setName string) error {
+			goFile, err := generateTemplGoFile(icon, setName)
+			if err != nil {
+				return fmt.Errorf("error generating go file for %s: %w", icon.Basename(), err)
+			}
+			outputGoPath := filepathPkg.Join(iconsPath, iconFileBase(icon)+".go")
+			if err := os.WriteFile(outputGoPath, []byte(goFile), 0644); err != nil {
+				return fmt.Errorf("error writing to output file %s: %w", outputGoPath, err)
+			}
+			return nil
+		},
+	})
+}
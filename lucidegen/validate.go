@@ -0,0 +1,99 @@
+package lucidegen
+
+import (
+	"fmt"
+	"regexp"
+
+	"golang.org/x/exp/slices"
+)
+
+var validIconName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// ValidateIconNames ensures that every icon and alias name can be turned into
+// a unique go identifier.
+func ValidateIconNames(icons []*LucideIconSvg) error {
+	funcNames := map[string]string{}
+	fileNames := map[string]string{}
+	for _, icon := range icons {
+		if !validIconName.MatchString(icon.KebabName()) {
+			return fmt.Errorf("invalid icon name '%s' (%s): names must be lowercase kebab case and start with a letter", icon.KebabName(), icon.LucideIconSvgPath)
+		}
+		if other, ok := funcNames[icon.CamelCaseName()]; ok {
+			return fmt.Errorf("icon '%s' and '%s' both generate the function %s", icon.KebabName(), other, icon.CamelCaseName())
+		}
+		funcNames[icon.CamelCaseName()] = icon.KebabName()
+		if other, ok := fileNames[iconFileBase(icon)]; ok {
+			return fmt.Errorf("icon '%s' and '%s' both generate the file %s", icon.KebabName(), other, iconFileBase(icon))
+		}
+		fileNames[iconFileBase(icon)] = icon.KebabName()
+	}
+	for _, icon := range icons {
+		for _, alias := range icon.LucideAliases {
+			if !validIconName.MatchString(string(alias)) {
+				return fmt.Errorf("invalid alias '%s' for icon '%s': names must be lowercase kebab case and start with a letter", alias, icon.KebabName())
+			}
+		}
+	}
+	return nil
+}
+
+// warnReservedNames prints a warning for each icon or alias whose function
+// got RESERVED_NAME_SUFFIX, since its name is reserved.
+func warnReservedNames(icons []*LucideIconSvg) {
+	for _, icon := range icons {
+		if name := kebabToCamelCase(icon.KebabName()); IsReserved(name) {
+			fmt.Printf("Warning: %s is reserved, icon '%s' generates the function %s\n", name, icon.KebabName(), icon.CamelCaseName())
+		}
+		for _, alias := range icon.LucideAliases {
+			if name := kebabToCamelCase(string(alias)); IsReserved(name) {
+				fmt.Printf("Warning: %s is reserved, alias '%s' for icon '%s' generates the function %s\n", name, alias, icon.KebabName(), alias.CamelCaseName())
+			}
+		}
+	}
+}
+
+// iconNames returns the kebab case names exported by each icon: its name
+// followed by its aliases. Aliases never shadow an icon or an earlier alias,
+// by name or by function, so that the rollup, the nodes, the stylesheet and
+// the svg files export the same names.
+func iconNames(icons []*LucideIconSvg) [][]string {
+	seen := map[string]bool{}
+	for _, icon := range icons {
+		seen[icon.KebabName()] = true
+		seen[icon.CamelCaseName()] = true
+	}
+	names := make([][]string, len(icons))
+	for i, icon := range icons {
+		names[i] = []string{icon.KebabName()}
+		for _, alias := range icon.LucideAliases {
+			if seen[string(alias)] || seen[alias.CamelCaseName()] {
+				continue
+			}
+			seen[string(alias)] = true
+			seen[alias.CamelCaseName()] = true
+			names[i] = append(names[i], string(alias))
+		}
+	}
+	return names
+}
+
+// FindCollisions returns the names (icon or alias) of the icons that are
+// also exported by the core icons.
+func FindCollisions(core []*LucideIconSvg, icons []*LucideIconSvg) []string {
+	coreNames := map[string]bool{}
+	for _, names := range iconNames(core) {
+		for _, name := range names {
+			coreNames[name] = true
+		}
+	}
+	collisions := []string{}
+	for _, names := range iconNames(icons) {
+		for _, name := range names {
+			if coreNames[name] {
+				collisions = append(collisions, name)
+			}
+		}
+	}
+	slices.Sort(collisions)
+	return collisions
+}
@@ -0,0 +1,20 @@
+package lucidegen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIconNames(t *testing.T) {
+	icons := []*LucideIconSvg{
+		{LucideIconSvgPath: "icons/house.svg", LucideAliases: []LucideIconAlias{"home", "pen"}},
+		{LucideIconSvgPath: "icons/pen.svg", LucideAliases: []LucideIconAlias{"home", "pencil", "node-icon"}},
+		{LucideIconSvgPath: "icons/node.svg"},
+	}
+	// aliases don't shadow an icon (pen), an earlier alias (home) or the
+	// function of an icon (node-icon is NodeIcon, the function of node)
+	want := [][]string{{"house", "home"}, {"pen", "pencil"}, {"node"}}
+	if got := iconNames(icons); !reflect.DeepEqual(got, want) {
+		t.Errorf("iconNames() = %v, want %v", got, want)
+	}
+}
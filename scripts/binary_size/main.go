@@ -0,0 +1,207 @@
+// Command binary_size measures how much of a generated icon package ends up in
+// a binary. It generates the package from a lucide checkout into a temporary
+// module, then builds programs that use no icons, a few icons, and every icon,
+// and reports their sizes and the number of icon functions linked in each.
+//
+// Run it on two commits to compare the generated packages before and after a
+// change:
+//
+//	go run ./scripts/binary_size -src ./dist/lucide -use 5
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	filepathPkg "path/filepath"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const (
+	SIZE_MODULE  = "lucidesize"
+	SIZE_PACKAGE = SIZE_MODULE + "/lucide"
+)
+
+type program struct {
+	Name  string
+	Icons []string
+}
+
+func main() {
+	srcPath := flag.String("src", "", "lucide repo checkout, or directory of svg (and json) files")
+	use := flag.Int("use", 5, "number of icons used by the small program")
+	generator := flag.String("generator", lucidegen.DEFAULT_GENERATOR, "generator to measure ("+strings.Join(lucidegen.Generators(), ", ")+")")
+	keep := flag.Bool("keep", false, "keep the temporary module")
+	flag.Parse()
+
+	if *srcPath == "" {
+		fmt.Fprintln(os.Stderr, "binary_size: -src is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*srcPath, *use, *generator, *keep); err != nil {
+		fmt.Fprintln(os.Stderr, "binary_size:", err)
+		os.Exit(1)
+	}
+}
+
+func run(srcPath string, use int, generator string, keep bool) error {
+	icons, err := lucidegen.IngestSource(srcPath)
+	if err != nil {
+		return err
+	}
+	if use > len(icons) {
+		use = len(icons)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "lucide-size-")
+	if err != nil {
+		return err
+	}
+	if keep {
+		fmt.Println("Keeping temporary module in", tmpDir)
+	} else {
+		defer os.RemoveAll(tmpDir)
+	}
+
+	// the package is generated as its own module, so that it requires the
+	// runtime version it was generated for
+	err = lucidegen.Generate(icons, lucidegen.Options{
+		Generator: generator,
+		Dir:       filepathPkg.Join(tmpDir, "lucide"),
+		Module:    SIZE_PACKAGE,
+		GoMod:     true,
+	})
+	if err != nil {
+		return err
+	}
+	goMod := strings.Join([]string{
+		"module " + SIZE_MODULE,
+		"",
+		"go 1.23",
+		"",
+		"require " + SIZE_PACKAGE + " v0.0.0",
+		"",
+		"replace " + SIZE_PACKAGE + " => ./lucide",
+		"",
+	}, "\n")
+	if err := os.WriteFile(filepathPkg.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		return err
+	}
+
+	allNames := []string{}
+	for _, icon := range icons {
+		allNames = append(allNames, icon.CamelCaseName())
+	}
+	programs := []program{
+		{Name: "none", Icons: nil},
+		{Name: fmt.Sprintf("use-%d", use), Icons: allNames[:use]},
+		{Name: "use-all", Icons: allNames},
+	}
+	for _, prog := range programs {
+		dir := filepathPkg.Join(tmpDir, "cmd", prog.Name)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepathPkg.Join(dir, "main.go"), []byte(programSource(generator, prog.Icons)), 0644); err != nil {
+			return err
+		}
+	}
+	if err := goCmd(filepathPkg.Join(tmpDir, "lucide"), "mod", "tidy"); err != nil {
+		return err
+	}
+	if err := goCmd(tmpDir, "mod", "tidy"); err != nil {
+		return err
+	}
+
+	fmt.Println("--------------------------------------")
+	fmt.Printf("%d icons, %s generator, %s\n", len(icons), generator, strings.TrimSpace(goOutput(tmpDir, "env", "GOVERSION")))
+	fmt.Printf("%-10s %12s %12s %14s\n", "program", "size", "delta", "icons linked")
+	var baseSize int64
+	for i, prog := range programs {
+		bin := filepathPkg.Join(tmpDir, "bin", prog.Name)
+		if err := goCmd(tmpDir, "build", "-trimpath", "-o", bin, "./cmd/"+prog.Name); err != nil {
+			return err
+		}
+		info, err := os.Stat(bin)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			baseSize = info.Size()
+		}
+		linked := countLinkedIcons(tmpDir, bin, allNames)
+		fmt.Printf("%-10s %12d %12d %9d/%d\n", prog.Name, info.Size(), info.Size()-baseSize, linked, len(allNames))
+	}
+	return nil
+}
+
+// programSource returns a main package rendering the icons.
+func programSource(generator string, names []string) string {
+	// the templ flavors render templ components
+	usesTempl := generator == "templ" || generator == "templ-go"
+	lines := []string{"package main", "", "import (", `	"io"`}
+	if len(names) > 0 {
+		if usesTempl {
+			lines = append(lines, `	"context"`)
+		}
+		lines = append(lines, `	icons "`+SIZE_PACKAGE+`"`)
+	}
+	lines = append(lines, ")", "", "func main() {")
+	for _, name := range names {
+		if usesTempl {
+			lines = append(lines, "	icons."+name+"().Render(context.Background(), io.Discard)")
+		} else {
+			lines = append(lines, "	icons."+name+"().Render(io.Discard)")
+		}
+	}
+	lines = append(lines, `	io.WriteString(io.Discard, "")`, "}", "")
+	return strings.Join(lines, "\n")
+}
+
+// countLinkedIcons returns how many icon functions of the icons subpackage
+// are present in the binary. Small icon functions are usually inlined, so the
+// closures they return (e.g. House.func1) and the svg element they render
+// (e.g. houseSvg) are counted as well.
+func countLinkedIcons(dir string, bin string, names []string) int {
+	symbols := map[string]bool{}
+	for _, line := range strings.Split(goOutput(dir, "tool", "nm", bin), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 {
+			symbols[fields[2]] = true
+		}
+	}
+	linked := 0
+	for _, name := range names {
+		symbol := SIZE_PACKAGE + "/icons." + name
+		svgSymbol := SIZE_PACKAGE + "/icons." + strings.ToLower(name[:1]) + name[1:] + "Svg"
+		if symbols[symbol] || symbols[symbol+".func1"] || symbols[svgSymbol] {
+			linked++
+		}
+	}
+	return linked
+}
+
+func goCmd(dir string, args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func goOutput(dir string, args ...string) string {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+	return out.String()
+}
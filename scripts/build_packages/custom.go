@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	filepathPkg "path/filepath"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const (
+	CUSTOM_DEFAULT_NAME    = "custom"
+	CUSTOM_DEFAULT_VERSION = "dev"
+)
+
+// buildCustomIconSet generates an icon package from an arbitrary directory of
+// svg (and optional json metadata) files. The package has the same api and
+// runtime helpers as the lucide package, under the module path given by
+// ICONS_MODULE and written to ICONS_OUTPUT. When several GENERATORS are
+// selected, each one is written to a subdirectory (and submodule) named after
+// the generator.
+func buildCustomIconSet(iconsDir string) error {
+	module := os.Getenv("ICONS_MODULE")
+	if module == "" {
+		return fmt.Errorf("ICONS_MODULE must be set to the go module path of the generated package")
+	}
+	outputPath := os.Getenv("ICONS_OUTPUT")
+	if outputPath == "" {
+		return fmt.Errorf("ICONS_OUTPUT must be set to the output directory of the generated package")
+	}
+	name := os.Getenv("ICONS_NAME")
+	if name == "" {
+		name = CUSTOM_DEFAULT_NAME
+	}
+	version := os.Getenv("ICONS_VERSION")
+	if version == "" {
+		version = CUSTOM_DEFAULT_VERSION
+	}
+	generatorNames := os.Getenv("GENERATORS")
+	if generatorNames == "" {
+		generatorNames = "templ"
+	}
+	outputs := []Output{}
+	for _, genName := range strings.Split(generatorNames, ",") {
+		outputs = append(outputs, Output{Generator: strings.TrimSpace(genName), Path: outputPath, Module: module})
+	}
+	if len(outputs) > 1 {
+		// every generator gets its own module within the output directory
+		for i := range outputs {
+			outputs[i].Path = filepathPkg.Join(outputPath, outputs[i].Generator)
+			outputs[i].Module = module + "/" + outputs[i].Generator
+		}
+	}
+
+	fmt.Printf("Reading icons from %s ...\n", iconsDir)
+	svgIcons, err := lucidegen.IngestIconsDir(iconsDir)
+	if err != nil {
+		return err
+	}
+	if len(svgIcons) == 0 {
+		return fmt.Errorf("no svg files found in %s", iconsDir)
+	}
+
+	if err := runGenerators(name, version, svgIcons, outputs); err != nil {
+		return err
+	}
+	fmt.Printf("Done writing %d %s icons to %s\n", len(svgIcons), name, outputPath)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+// IconSet describes an upstream icon repository and the go package
+// that is generated from it.
+type IconSet struct {
+	// Name used to select the set through the ICON_SET env var
+	Name string
+	// Human readable name used in the generated doc comments
+	DisplayName string
+	Owner       string
+	Repo        string
+	// Directory where the upstream releases.json is saved
+	ReleasesDir string
+	MinVersion  string
+	GitURL      string
+	GitDir      string
+	// Generated packages, the first one is used to track the synced versions
+	Outputs []Output
+	// Fail the build when an icon or alias name is also used by core lucide
+	CollidesWithCore bool
+}
+
+var lucideIconSet = IconSet{
+	Name:        "lucide",
+	DisplayName: "Lucide",
+	Owner:       LUCIDE_OWNER,
+	Repo:        LUCIDE_REPO,
+	ReleasesDir: LUCIDE_DIR,
+	MinVersion:  MIN_LUCIDE_VERSION,
+	GitURL:      LUCIDE_GIT_URL,
+	GitDir:      LUCIDE_GIT_DIR,
+	Outputs: []Output{
+		{Generator: "templ", GitURL: TEMPL_GIT_URL, Path: TEMPL_SUBMODULE_PATH, Module: TEMPL_MODULE},
+		{Generator: "gomponents", GitURL: GOMPONENTS_GIT_URL, Path: GOMPONENTS_SUBMODULE_PATH, Module: GOMPONENTS_MODULE},
+		// the font repo also persists the codepoints of the glyphs
+		{Generator: "font", GitURL: FONT_GIT_URL, Path: FONT_SUBMODULE_PATH, Module: FONT_MODULE},
+	},
+}
+
+var labIconSet = IconSet{
+	Name:        "lab",
+	DisplayName: "Lucide Lab",
+	Owner:       LUCIDE_OWNER,
+	Repo:        LAB_REPO,
+	ReleasesDir: LAB_DIR,
+	MinVersion:  MIN_LAB_VERSION,
+	GitURL:      LAB_GIT_URL,
+	GitDir:      LAB_GIT_DIR,
+	Outputs: []Output{
+		{Generator: "templ", GitURL: LAB_TEMPL_GIT_URL, Path: LAB_TEMPL_SUBMODULE_PATH, Module: LAB_TEMPL_MODULE},
+	},
+	CollidesWithCore: true,
+}
+
+var iconSets = []IconSet{lucideIconSet, labIconSet}
+
+func findIconSet(name string) (IconSet, error) {
+	if name == "" {
+		return lucideIconSet, nil
+	}
+	for _, set := range iconSets {
+		if set.Name == name {
+			return set, nil
+		}
+	}
+	names := []string{}
+	for _, set := range iconSets {
+		names = append(names, set.Name)
+	}
+	return IconSet{}, fmt.Errorf("unknown icon set '%s' (available: %s)", name, strings.Join(names, ", "))
+}
+
+// injestCoreIcons checks out the latest lucide release and reads its icons,
+// so that other icon sets can be checked for collisions against them.
+func injestCoreIcons() ([]*lucidegen.LucideIconSvg, error) {
+	releases, err := fetchReleases(LUCIDE_OWNER, LUCIDE_REPO)
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases found for %s/%s", LUCIDE_OWNER, LUCIDE_REPO)
+	}
+	if err := cloneRepo(LUCIDE_GIT_URL, LUCIDE_GIT_DIR); err != nil {
+		return nil, err
+	}
+	if err := fetchTags(LUCIDE_GIT_DIR); err != nil {
+		return nil, err
+	}
+	fmt.Printf("  Checking out core lucide tag %s\n", releases[0].TagName)
+	checkoutTag(releases[0].TagName, LUCIDE_GIT_DIR)
+	return lucidegen.IngestIcons(LUCIDE_GIT_DIR)
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	filepathPkg "path/filepath"
 	"time"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
 )
 
 const (
@@ -16,33 +18,74 @@ const (
 	LUCIDE_GIT_DIR       = "./dist/lucide"
 	TEMPL_GIT_URL        = "git@github.com:bryanvaz/go-templ-lucide-icons.git"
 	TEMPL_SUBMODULE_PATH = "./dist/go-templ-lucide-icons"
-	TEMPL_UTILS_PATH     = "./src/templ"
+	TEMPL_MODULE         = "github.com/bryanvaz/go-templ-lucide-icons"
+
+	GOMPONENTS_GIT_URL        = "git@github.com:bryanvaz/go-gomponents-lucide-icons.git"
+	GOMPONENTS_SUBMODULE_PATH = "./dist/go-gomponents-lucide-icons"
+	GOMPONENTS_MODULE         = "github.com/bryanvaz/go-gomponents-lucide-icons"
+
+	FONT_GIT_URL        = "git@github.com:bryanvaz/go-lucide-icon-font.git"
+	FONT_SUBMODULE_PATH = "./dist/go-lucide-icon-font"
+	FONT_MODULE         = "github.com/bryanvaz/go-lucide-icon-font"
+
+	LAB_REPO                 = "lucide-lab"
+	LAB_DIR                  = "./lucide-lab"
+	MIN_LAB_VERSION          = "2024-01-01"
+	LAB_GIT_URL              = "https://github.com/lucide-icons/lucide-lab.git"
+	LAB_GIT_DIR              = "./dist/lucide-lab"
+	LAB_TEMPL_GIT_URL        = "git@github.com:bryanvaz/go-templ-lucide-lab-icons.git"
+	LAB_TEMPL_SUBMODULE_PATH = "./dist/go-templ-lucide-lab-icons"
+	LAB_TEMPL_MODULE         = "github.com/bryanvaz/go-templ-lucide-lab-icons"
 )
 
 func main() {
+	if iconsDir := os.Getenv("ICONS_DIR"); iconsDir != "" {
+		if err := buildCustomIconSet(iconsDir); err != nil {
+			fmt.Println("Error building custom icon set:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	InitializeGhClient()
-	versionsAfterTime, err := time.Parse("2006-01-02", MIN_LUCIDE_VERSION)
+	set, err := findIconSet(os.Getenv("ICON_SET"))
+	if err != nil {
+		fmt.Println("Error selecting icon set:", err)
+		os.Exit(1)
+	}
+	versionsAfterTime, err := time.Parse("2006-01-02", set.MinVersion)
 	if err != nil {
 		fmt.Println("Error parsing date:", err)
 		return
 	}
 
+	outputs, err := selectOutputs(set.Outputs, os.Getenv("GENERATORS"))
+	if err != nil {
+		fmt.Println("Error selecting generators:", err)
+		os.Exit(1)
+	}
+
 	targetTag := os.Getenv("TARGET")
 	if targetTag != "" {
-		fmt.Printf("Syncing lucide icon releases for tag %s\n", targetTag)
+		fmt.Printf("Syncing %s icon releases for tag %s\n", set.DisplayName, targetTag)
 	}
 
-	// sync lucide icon versions
-	fmt.Println("Syncing lucide icon releases...")
-	lucideReleases, err := fetchReleases(LUCIDE_OWNER, LUCIDE_REPO)
+	// sync upstream icon versions
+	fmt.Printf("Syncing %s icon releases...\n", set.DisplayName)
+	lucideReleases, err := fetchReleases(set.Owner, set.Repo)
 	if err != nil {
 		fmt.Println("Error fetching releases:", err)
 		os.Exit(1)
 	}
-	releasesJsonPath := filepathPkg.Join(LUCIDE_DIR, "releases.json")
+	if len(lucideReleases) == 0 {
+		fmt.Printf("No releases found for %s/%s\n", set.Owner, set.Repo)
+		os.Exit(1)
+	}
+	os.MkdirAll(set.ReleasesDir, os.ModePerm)
+	releasesJsonPath := filepathPkg.Join(set.ReleasesDir, "releases.json")
 	saveReleasesToFile(lucideReleases, releasesJsonPath)
 	releases := filterReleasesAfter(lucideReleases, versionsAfterTime)
-	fmt.Printf("  Latest Lucide release: %s (%s)\n", lucideReleases[0].TagName, lucideReleases[0].PublishedAt)
+	fmt.Printf("  Latest %s release: %s (%s)\n", set.DisplayName, lucideReleases[0].TagName, lucideReleases[0].PublishedAt)
 	if targetTag != "" {
 		releaseFound := false
 		for _, release := range releases {
@@ -52,33 +95,46 @@ func main() {
 			}
 		}
 		if !releaseFound {
-			fmt.Printf("Release %s not found in upstream lucide repo\n", targetTag)
+			fmt.Printf("Release %s not found in upstream %s repo\n", targetTag, set.Repo)
 			os.Exit(1)
 		}
 	}
 
-	// sync the repo into the dist directory
-	fmt.Println("Syncing templ-lucide icon repo...")
+	// sync the output repos into the dist directory
 	os.MkdirAll("./dist", os.ModePerm)
-	err = cloneRepo(TEMPL_GIT_URL, TEMPL_SUBMODULE_PATH)
-	if err != nil {
-		fmt.Println("Error cloning templ icon repo:", err)
+	for _, out := range outputs {
+		if out.GitURL == "" {
+			continue
+		}
+		fmt.Printf("Syncing %s icon repo...\n", out.Module)
+		if err := cloneRepo(out.GitURL, out.Path); err != nil {
+			fmt.Printf("Error cloning %s icon repo: %s\n", out.Generator, err)
+			os.Exit(1)
+		}
+	}
+	versionOutput := set.Outputs[0]
+	if versionOutput.GitURL == "" {
+		fmt.Printf("Output %s has no repo to track the synced versions\n", versionOutput.Module)
+		os.Exit(1)
+	}
+	if err := cloneRepo(versionOutput.GitURL, versionOutput.Path); err != nil {
+		fmt.Printf("Error cloning %s icon repo: %s\n", versionOutput.Generator, err)
 		os.Exit(1)
 	}
-	err = fetchTags(TEMPL_SUBMODULE_PATH)
+	err = fetchTags(versionOutput.Path)
 	if err != nil {
 		fmt.Println("Error fetching tags:", err)
 		os.Exit(1)
 	}
-	tags, err := getGitTags(TEMPL_SUBMODULE_PATH)
+	tags, err := getGitTags(versionOutput.Path)
 	if err != nil {
 		fmt.Println("Error fetching tags:", err)
 		return
 	}
 	if len(tags) == 0 {
-		fmt.Println("No tags found in templ-lucide")
+		fmt.Printf("No tags found in %s\n", versionOutput.Module)
 	} else {
-		fmt.Printf("  Latest templ-lucide release: %s\n", tags[len(tags)-1])
+		fmt.Printf("  Latest %s release: %s\n", versionOutput.Module, tags[len(tags)-1])
 	}
 
 	// build list of missing tags starting from latest tag
@@ -92,20 +148,20 @@ func main() {
 				break
 			}
 		}
-		fmt.Printf("  Syncing lucide icon releases for tag %s\n", targetTag)
+		fmt.Printf("  Syncing %s icon releases for tag %s\n", set.DisplayName, targetTag)
 
 	} else {
 		fmt.Println("  Next release to sync:", currRel.TagName)
 	}
 
 	// sync the repo into the tmp directory
-	fmt.Println("Syncing upstream lucide repo for icons ...")
-	err = cloneRepo(LUCIDE_GIT_URL, LUCIDE_GIT_DIR)
+	fmt.Printf("Syncing upstream %s repo for icons ...\n", set.Repo)
+	err = cloneRepo(set.GitURL, set.GitDir)
 	if err != nil {
-		fmt.Println("Error cloning lucide repo:", err)
+		fmt.Printf("Error cloning %s repo: %s\n", set.Repo, err)
 		os.Exit(1)
 	}
-	err = fetchTags(LUCIDE_GIT_DIR)
+	err = fetchTags(set.GitDir)
 	if err != nil {
 		fmt.Println("Error fetching tags:", err)
 		os.Exit(1)
@@ -113,113 +169,37 @@ func main() {
 
 	// switch to tag to sync
 	fmt.Printf("  Checking out tag %s\n", currRel.TagName)
-	checkoutTag(currRel.TagName, LUCIDE_GIT_DIR)
-	svgIcons, err := injestIcons(LUCIDE_GIT_DIR)
+	checkoutTag(currRel.TagName, set.GitDir)
+	svgIcons, err := lucidegen.IngestIcons(set.GitDir)
 	if err != nil {
 		fmt.Println("Error reading icons:", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("--------------------------------------")
-	// Delete the templ output folder if it exists
-	submoduleTemplPath := filepathPkg.Join(TEMPL_SUBMODULE_PATH, "icons")
-	fmt.Println("Cleaning up old templ files...")
-	if _, err := os.Stat(submoduleTemplPath); err == nil {
-		err := os.RemoveAll(submoduleTemplPath)
-		if err != nil {
-			fmt.Println("Error deleting folder:", err)
-			os.Exit(1)
-		}
-	}
-	if _, err := os.Stat(filepathPkg.Join(submoduleTemplPath, "VERSION")); err == nil {
-		err := os.Remove(filepathPkg.Join(submoduleTemplPath, "VERSION"))
-		if err != nil {
-			fmt.Println("Error deleting file:", err)
-			os.Exit(1)
-		}
-	}
-
-	// generate templ file
-	fmt.Printf("Generating templ and go files for %d icons ...\n", len(svgIcons))
-	templFiles := make(map[string]string)
-	goFiles := make(map[string]string)
-	for _, icon := range svgIcons {
-		templFunc, err := generateTemplFunc(icon)
-		if err != nil {
-			fmt.Println("Error generating templ func:", err)
-			os.Exit(1)
-		}
-		templFile, err := generateTemplFile(templFunc)
-		if err != nil {
-			fmt.Println("Error generating templ file:", err)
-			os.Exit(1)
-		}
-		templFiles[icon.Basename()] = templFile
-		goFile, err := generateGoFromTempl(templFile)
-		if err != nil {
-			fmt.Println("Error generating go file:", err)
-			os.Exit(1)
-		}
-		goFiles[icon.Basename()] = goFile
-	}
-
-	fmt.Printf("Writing templ and go files ...\n")
-	if err := os.MkdirAll(submoduleTemplPath, os.ModePerm); err != nil {
-		fmt.Println("Error creating folder:", err)
+	if err := lucidegen.ValidateIconNames(svgIcons); err != nil {
+		fmt.Println("Error validating icons:", err)
 		os.Exit(1)
 	}
-	for basename, templFile := range templFiles {
-		outputTemplPath := filepathPkg.Join(submoduleTemplPath, basename+".templ")
-		outputGoPath := filepathPkg.Join(submoduleTemplPath, basename+"_templ.go")
-		if err := os.WriteFile(outputTemplPath, []byte(templFile), 0644); err != nil {
-			fmt.Println("Error writing to output file:", outputTemplPath, err)
+	if set.CollidesWithCore {
+		fmt.Println("Checking for collisions with core lucide icons ...")
+		coreIcons, err := injestCoreIcons()
+		if err != nil {
+			fmt.Println("Error reading core lucide icons:", err)
 			os.Exit(1)
 		}
-		if err := os.WriteFile(outputGoPath, []byte(goFiles[basename]), 0644); err != nil {
-			fmt.Println("Error writing to output file:", outputGoPath, err)
+		if collisions := lucidegen.FindCollisions(coreIcons, svgIcons); len(collisions) > 0 {
+			fmt.Printf("Found %d %s icon names that collide with core lucide icons:\n", len(collisions), set.DisplayName)
+			for _, name := range collisions {
+				fmt.Printf("  %s\n", name)
+			}
 			os.Exit(1)
 		}
 	}
 
-	// Write VERSION file
-	if err := os.WriteFile(filepathPkg.Join(TEMPL_SUBMODULE_PATH, "VERSION"), []byte(currRel.TagName), 0644); err != nil {
-		fmt.Println("Error writing to VERSION file:", err)
-		os.Exit(1)
-	}
-
-	// Copy utils.go file
-	srcUtilsPath := filepathPkg.Join(TEMPL_UTILS_PATH, "utils.go")
-	dstUtilsPath := filepathPkg.Join(TEMPL_SUBMODULE_PATH, "icons", "utils.go")
-	if err := copyFile(srcUtilsPath, dstUtilsPath); err != nil {
-		fmt.Println("Error copying utils.go:", err)
-	}
-	fmt.Println("Copied utils.go")
-	srcDefaultAttributesPath := filepathPkg.Join(TEMPL_UTILS_PATH, "default_attributes.go")
-	destDefaultAttributesPath := filepathPkg.Join(TEMPL_SUBMODULE_PATH, "icons", "default_attributes.go")
-	if err := copyFile(srcDefaultAttributesPath, destDefaultAttributesPath); err != nil {
-		fmt.Println("Error copying default_attributes.go:", err)
-	}
-	fmt.Println("Copied default_attributes.go")
-
-	rollupFile, err := createRollupFile(svgIcons)
-	if err != nil {
-		fmt.Println("Error creating rollup file:", err)
-		os.Exit(1)
-	}
-	rollupFilePath := filepathPkg.Join(TEMPL_SUBMODULE_PATH, "icons.go")
-	if err := os.WriteFile(rollupFilePath, []byte(rollupFile), 0644); err != nil {
-		fmt.Println("Error writing to rollup file:", err)
+	if err := runGenerators(set.DisplayName, currRel.TagName, svgIcons, outputs); err != nil {
+		fmt.Println("Error generating packages:", err)
 		os.Exit(1)
 	}
-	fmt.Println("Rollup file saved to", rollupFilePath)
 
 	fmt.Printf("Done writing files for release %s \n", currRel.TagName)
 }
-
-func copyFile(src, dst string) error {
-	data, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(dst, data, 0644)
-}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+// Output is a package generated from an icon set by one of the generators.
+type Output struct {
+	Generator string
+	// Repo the output is published to (optional)
+	GitURL string
+	Path   string
+	Module string
+}
+
+// selectOutputs returns the outputs whose generator is listed in the comma
+// separated selection, or every output if the selection is empty.
+func selectOutputs(outputs []Output, selection string) ([]Output, error) {
+	if selection == "" {
+		return outputs, nil
+	}
+	selected := []Output{}
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.TrimSpace(name)
+		if _, err := lucidegen.FindGenerator(name); err != nil {
+			return nil, err
+		}
+		found := false
+		for _, out := range outputs {
+			if out.Generator == name {
+				selected = append(selected, out)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no output configured for generator '%s'", name)
+		}
+	}
+	return selected, nil
+}
+
+// runGenerators writes every output of the icon set. The svgs are optimized
+// first when OPTIMIZE is set to true, and STROKE_WIDTH sets the stroke width
+// of the icon font glyphs.
+func runGenerators(setName string, version string, icons []*lucidegen.LucideIconSvg, outputs []Output) error {
+	optimize := os.Getenv("OPTIMIZE") == "true"
+	strokeWidth := 0.0
+	if value := os.Getenv("STROKE_WIDTH"); value != "" {
+		var err error
+		if strokeWidth, err = strconv.ParseFloat(value, 64); err != nil || strokeWidth <= 0 {
+			return fmt.Errorf("invalid STROKE_WIDTH '%s'", value)
+		}
+	}
+	for _, out := range outputs {
+		fmt.Println("--------------------------------------")
+		fmt.Printf("Running %s generator for %s ...\n", out.Generator, out.Module)
+		err := lucidegen.Generate(icons, lucidegen.Options{
+			Generator:   out.Generator,
+			Dir:         out.Path,
+			Module:      out.Module,
+			SetName:     setName,
+			Version:     version,
+			GoMod:       true,
+			Optimize:    optimize,
+			StrokeWidth: strokeWidth,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
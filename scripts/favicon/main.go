@@ -0,0 +1,339 @@
+// Command favicon exports a favicon and app icon pack from a single icon of a
+// lucide checkout (or any directory of svg files): a multi-size favicon.ico,
+// an svg favicon, png icons for apple-touch-icon and PWAs, and a web manifest
+// snippet listing them. The output only depends on the icon and the flags, so
+// the pack can be regenerated for any Lucide version.
+//
+//	go run ./scripts/favicon -src ./dist/lucide -icon house -color '#2563eb' -background '#ffffff' -out ./favicon
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	filepathPkg "path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+	icons "github.com/bryanvaz/go-lucide/src/common"
+)
+
+var (
+	// Sizes embedded in favicon.ico
+	ICO_SIZES = []int{16, 32, 48}
+	// Minimum padding of maskable icons, to keep the icon in the safe zone
+	MASKABLE_MIN_PADDING = 0.2
+)
+
+// pngIcon is a png file of the pack.
+type pngIcon struct {
+	File     string
+	Size     int
+	Maskable bool
+	// Listed in the web manifest
+	Manifest bool
+}
+
+var pngIcons = []pngIcon{
+	{File: "apple-touch-icon.png", Size: 180},
+	{File: "icon-192.png", Size: 192, Manifest: true},
+	{File: "icon-512.png", Size: 512, Manifest: true},
+	{File: "icon-maskable-512.png", Size: 512, Maskable: true, Manifest: true},
+}
+
+type packOptions struct {
+	Color       color.RGBA
+	ColorValue  string
+	Background  *color.RGBA
+	BgValue     string
+	Padding     float64
+	StrokeWidth float64
+}
+
+func main() {
+	srcPath := flag.String("src", "", "lucide repo checkout, or directory of svg (and json) files")
+	iconName := flag.String("icon", "", "name or alias of the icon")
+	outPath := flag.String("out", "favicon", "output directory")
+	colorValue := flag.String("color", "#000000", "color of the icon (#rgb or #rrggbb)")
+	bgValue := flag.String("background", "", "background color (#rgb or #rrggbb), transparent if empty")
+	padding := flag.Float64("padding", 0.1, "padding around the icon, as a fraction of the size")
+	strokeWidth := flag.Float64("stroke-width", 0, "stroke width in viewBox units (default: the icon's)")
+	flag.Parse()
+
+	if *srcPath == "" || *iconName == "" {
+		fmt.Fprintln(os.Stderr, "favicon: -src and -icon are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	opts := packOptions{ColorValue: *colorValue, BgValue: *bgValue, Padding: *padding, StrokeWidth: *strokeWidth}
+	var err error
+	if opts.Color, err = parseHexColor(*colorValue); err != nil {
+		fmt.Fprintln(os.Stderr, "favicon: -color:", err)
+		os.Exit(2)
+	}
+	if *bgValue != "" {
+		bg, err := parseHexColor(*bgValue)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "favicon: -background:", err)
+			os.Exit(2)
+		}
+		opts.Background = &bg
+	}
+	if opts.Padding < 0 || opts.Padding >= 0.5 {
+		fmt.Fprintln(os.Stderr, "favicon: -padding must be between 0 and 0.5")
+		os.Exit(2)
+	}
+	if err := run(*srcPath, *iconName, *outPath, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "favicon:", err)
+		os.Exit(1)
+	}
+}
+
+func run(srcPath string, iconName string, outPath string, opts packOptions) error {
+	svgIcons, err := lucidegen.IngestSource(srcPath)
+	if err != nil {
+		return err
+	}
+	found, err := lucidegen.Filter(svgIcons, iconName)
+	if err != nil {
+		return err
+	}
+	svg, err := found[0].Svg()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outPath, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+
+	ico, err := encodeIco(svg, opts)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(outPath, "favicon.ico", ico); err != nil {
+		return err
+	}
+	if err := writeFile(outPath, "icon.svg", []byte(faviconSvg(svg, opts))); err != nil {
+		return err
+	}
+	for _, icon := range pngIcons {
+		iconOpts := opts
+		if icon.File == "apple-touch-icon.png" && iconOpts.Background == nil {
+			// iOS fills transparent pixels with black
+			iconOpts.Background = &color.RGBA{0xff, 0xff, 0xff, 0xff}
+		}
+		if icon.Maskable {
+			iconOpts.Padding = math.Max(opts.Padding, MASKABLE_MIN_PADDING)
+			if iconOpts.Background == nil {
+				iconOpts.Background = &color.RGBA{0xff, 0xff, 0xff, 0xff}
+			}
+		}
+		img, err := render(svg, icon.Size, iconOpts)
+		if err != nil {
+			return err
+		}
+		data, err := encodePng(img)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(outPath, icon.File, data); err != nil {
+			return err
+		}
+	}
+	manifest, err := manifestSnippet()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(outPath, "manifest.webmanifest", manifest); err != nil {
+		return err
+	}
+
+	fmt.Println("--------------------------------------")
+	fmt.Println("Add to the <head> of your pages:")
+	fmt.Println(`<link rel="icon" href="/favicon.ico" sizes="any">`)
+	fmt.Println(`<link rel="icon" href="/icon.svg" type="image/svg+xml">`)
+	fmt.Println(`<link rel="apple-touch-icon" href="/apple-touch-icon.png">`)
+	fmt.Println(`<link rel="manifest" href="/manifest.webmanifest">`)
+	return nil
+}
+
+// render draws the icon on a size x size image, over the background.
+func render(svg *lucidegen.Svg, size int, opts packOptions) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	if opts.Background != nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(*opts.Background), image.Point{}, draw.Src)
+	}
+	inset := int(math.Round(float64(size) * opts.Padding))
+	root := icons.Node{Tag: "svg"}
+	for _, attr := range svg.RootAttrs {
+		root.Attrs = append(root.Attrs, icons.Attr{Name: attr.Name, Value: attr.Value})
+	}
+	node := icons.IconNode{}
+	for _, el := range svg.Elements {
+		n := icons.Node{Tag: el.Tag}
+		for _, attr := range el.Attrs {
+			n.Attrs = append(n.Attrs, icons.Attr{Name: attr.Name, Value: attr.Value})
+		}
+		node = append(node, n)
+	}
+	err := icons.DrawSvg(img, img.Bounds().Inset(inset), root, node, icons.RasterOptions{
+		Color:       opts.Color,
+		StrokeWidth: opts.StrokeWidth,
+	})
+	return img, err
+}
+
+func encodePng(img image.Image) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// encodeIco writes a favicon.ico holding a png image for each of ICO_SIZES.
+func encodeIco(svg *lucidegen.Svg, opts packOptions) ([]byte, error) {
+	images := [][]byte{}
+	for _, size := range ICO_SIZES {
+		img, err := render(svg, size, opts)
+		if err != nil {
+			return nil, err
+		}
+		data, err := encodePng(img)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, data)
+	}
+	var b bytes.Buffer
+	// ICONDIR: reserved, type (1 = icon), number of images
+	binary.Write(&b, binary.LittleEndian, [3]uint16{0, 1, uint16(len(images))})
+	offset := 6 + 16*len(images)
+	for i, data := range images {
+		// ICONDIRENTRY: width, height (0 means 256), palette size, reserved,
+		// color planes, bits per pixel, data size, data offset
+		dim := uint8(ICO_SIZES[i] % 256)
+		b.Write([]byte{dim, dim, 0, 0})
+		binary.Write(&b, binary.LittleEndian, [2]uint16{1, 32})
+		binary.Write(&b, binary.LittleEndian, [2]uint32{uint32(len(data)), uint32(offset)})
+		offset += len(data)
+	}
+	for _, data := range images {
+		b.Write(data)
+	}
+	return b.Bytes(), nil
+}
+
+// faviconSvg returns the icon as a standalone svg, with the color, background
+// and padding of the pack.
+func faviconSvg(svg *lucidegen.Svg, opts packOptions) string {
+	viewBox := []float64{0, 0, 24, 24}
+	if value, ok := svg.Attr("viewBox"); ok {
+		if fields := strings.Fields(strings.ReplaceAll(value, ",", " ")); len(fields) == 4 {
+			for i, field := range fields {
+				viewBox[i], _ = strconv.ParseFloat(field, 64)
+			}
+		}
+	}
+	// padding is a fraction of the whole image, so the viewBox grows by
+	// padding / (1 - 2 * padding) of the icon on each side
+	side := math.Max(viewBox[2], viewBox[3])
+	pad := side * opts.Padding / (1 - 2*opts.Padding)
+	x, y := viewBox[0]-pad-(side-viewBox[2])/2, viewBox[1]-pad-(side-viewBox[3])/2
+	full := side + 2*pad
+	// rounded to 4 decimals, the padding arithmetic leaves float noise
+	// (e.g. -3.0000000000000004), + 0 turns -0 into 0
+	format := func(v float64) string {
+		return strconv.FormatFloat(math.Round(v*1e4)/1e4+0, 'f', -1, 64)
+	}
+
+	// currentColor is resolved, as favicons don't inherit a color
+	attrs := []string{
+		`xmlns="http://www.w3.org/2000/svg"`,
+		fmt.Sprintf(`viewBox="%s %s %s %s"`, format(x), format(y), format(full), format(full)),
+		`color="` + html.EscapeString(opts.ColorValue) + `"`,
+	}
+	for _, attr := range svg.RootAttrs {
+		switch attr.Name {
+		case "xmlns", "viewBox", "width", "height", "class", "color":
+			continue
+		case "stroke-width":
+			if opts.StrokeWidth > 0 {
+				attr.Value = format(opts.StrokeWidth)
+			}
+		}
+		attrs = append(attrs, attr.Name+`="`+html.EscapeString(attr.Value)+`"`)
+	}
+	lines := []string{"<svg " + strings.Join(attrs, " ") + ">"}
+	if opts.Background != nil {
+		lines = append(lines, fmt.Sprintf(`  <rect x="%s" y="%s" width="%s" height="%s" fill="%s" stroke="none" />`, format(x), format(y), format(full), format(full), html.EscapeString(opts.BgValue)))
+	}
+	for _, el := range svg.Elements {
+		lines = append(lines, "  "+el.Markup())
+	}
+	lines = append(lines, "</svg>", "")
+	return strings.Join(lines, "\n")
+}
+
+// manifestSnippet returns the icons member of a web manifest.
+func manifestSnippet() ([]byte, error) {
+	type manifestIcon struct {
+		Src     string `json:"src"`
+		Sizes   string `json:"sizes"`
+		Type    string `json:"type"`
+		Purpose string `json:"purpose,omitempty"`
+	}
+	manifestIcons := []manifestIcon{}
+	for _, icon := range pngIcons {
+		if !icon.Manifest {
+			continue
+		}
+		purpose := ""
+		if icon.Maskable {
+			purpose = "maskable"
+		}
+		manifestIcons = append(manifestIcons, manifestIcon{
+			Src:     "/" + icon.File,
+			Sizes:   fmt.Sprintf("%dx%d", icon.Size, icon.Size),
+			Type:    "image/png",
+			Purpose: purpose,
+		})
+	}
+	data, err := json.MarshalIndent(map[string]any{"icons": manifestIcons}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func writeFile(dir string, name string, data []byte) error {
+	path := filepathPkg.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing to output file %s: %w", path, err)
+	}
+	fmt.Println("Wrote", path)
+	return nil
+}
+
+func parseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 || !strings.HasPrefix(value, "#") {
+		return color.RGBA{}, fmt.Errorf("invalid color '%s', expected #rgb or #rrggbb", value)
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}, nil
+}
@@ -0,0 +1,171 @@
+// Command render_bench measures the time and allocations of rendering icons
+// of a generated package. It generates the package from a lucide checkout into
+// a temporary module, along with Benchmark functions rendering an icon with
+// the default attributes (BenchmarkDefault), with a few attributes
+// (BenchmarkSizeClass) and with many attributes (BenchmarkCustom), and runs
+// them with go test.
+//
+// Run it on the commits before and after a change to the runtime, and compare
+// the outputs with benchstat:
+//
+//	go run ./scripts/render_bench -src ./dist/lucide -generator gomponents -count 10
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	filepathPkg "path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const (
+	BENCH_MODULE  = "lucidebench"
+	BENCH_PACKAGE = BENCH_MODULE + "/lucide"
+)
+
+// Attributes passed to the icon by each benchmark, as go source
+var benchCases = []struct {
+	Func  string
+	Attrs string
+}{
+	{"Default", ""},
+	{"SizeClass", `{"size": "32", "class": "nav-icon"}`},
+	{"Custom", `{"size": "48", "color": "#2563eb", "stroke-width": "1", "absoluteStrokeWidth": true}, {"class": "icon active", "aria-hidden": "true", "data-id": "42"}`},
+}
+
+func main() {
+	srcPath := flag.String("src", "", "lucide repo checkout, or directory of svg (and json) files")
+	icon := flag.String("icon", "house", "icon rendered by the benchmarks")
+	generator := flag.String("generator", lucidegen.DEFAULT_GENERATOR, "generator to measure ("+strings.Join(lucidegen.Generators(), ", ")+")")
+	count := flag.Int("count", 1, "number of runs of each benchmark (e.g. 10 for benchstat)")
+	keep := flag.Bool("keep", false, "keep the temporary module")
+	flag.Parse()
+
+	if *srcPath == "" {
+		fmt.Fprintln(os.Stderr, "render_bench: -src is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*srcPath, *icon, *generator, *count, *keep); err != nil {
+		fmt.Fprintln(os.Stderr, "render_bench:", err)
+		os.Exit(1)
+	}
+}
+
+func run(srcPath string, iconName string, generator string, count int, keep bool) error {
+	icons, err := lucidegen.IngestSource(srcPath)
+	if err != nil {
+		return err
+	}
+	icons, err = lucidegen.Filter(icons, iconName)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "lucide-bench-")
+	if err != nil {
+		return err
+	}
+	if keep {
+		fmt.Println("Keeping temporary module in", tmpDir)
+	} else {
+		defer os.RemoveAll(tmpDir)
+	}
+
+	err = lucidegen.Generate(icons, lucidegen.Options{
+		Generator: generator,
+		Dir:       filepathPkg.Join(tmpDir, "lucide"),
+		Module:    BENCH_PACKAGE,
+		GoMod:     true,
+	})
+	if err != nil {
+		return err
+	}
+	goMod := strings.Join([]string{
+		"module " + BENCH_MODULE,
+		"",
+		"go 1.23",
+		"",
+		"require " + BENCH_PACKAGE + " v0.0.0",
+		"",
+		"replace " + BENCH_PACKAGE + " => ./lucide",
+		"",
+	}, "\n")
+	if err := os.WriteFile(filepathPkg.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		return err
+	}
+	mainSource := "package main\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepathPkg.Join(tmpDir, "main.go"), []byte(mainSource), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepathPkg.Join(tmpDir, "render_test.go"), []byte(testSource(generator, icons[0].CamelCaseName())), 0644); err != nil {
+		return err
+	}
+	if err := goCmd(filepathPkg.Join(tmpDir, "lucide"), "mod", "tidy"); err != nil {
+		return err
+	}
+	if err := goCmd(tmpDir, "mod", "tidy"); err != nil {
+		return err
+	}
+	fmt.Println("--------------------------------------")
+	fmt.Printf("%s icon, %s generator\n", icons[0].KebabName(), generator)
+	return goCmd(tmpDir, "test", "-run", "^$", "-bench", ".", "-benchmem", "-count", strconv.Itoa(count))
+}
+
+// testSource returns a test file with a Benchmark function per bench case.
+func testSource(generator string, funcName string) string {
+	// the templ flavors render templ components
+	usesTempl := generator == "templ" || generator == "templ-go"
+	attrsType := "icons.Attrs"
+	render := "icons." + funcName + "(%s).Render(&buf)"
+	imports := []string{`"bytes"`, `"testing"`, `icons "` + BENCH_PACKAGE + `"`}
+	if usesTempl {
+		attrsType = "templ.Attributes"
+		render = "icons." + funcName + "(%s).Render(ctx, &buf)"
+		imports = append(imports, `"context"`, `"github.com/a-h/templ"`)
+	}
+	lines := []string{"package main", "", "import ("}
+	for _, imp := range imports {
+		lines = append(lines, "\t"+imp)
+	}
+	lines = append(lines, ")", "")
+	for _, c := range benchCases {
+		args := ""
+		if c.Attrs != "" {
+			args = "attrs..."
+		}
+		lines = append(lines, "func Benchmark"+c.Func+"(b *testing.B) {")
+		if usesTempl {
+			// icons are usually rendered in a page, which initialized the context
+			lines = append(lines, "\tctx := templ.InitializeContext(context.Background())")
+		}
+		lines = append(lines,
+			fmt.Sprintf("\tattrs := []%s{%s}", attrsType, c.Attrs),
+			"\t_ = attrs",
+			"\tvar buf bytes.Buffer",
+			"\tb.ReportAllocs()",
+			"\tfor i := 0; i < b.N; i++ {",
+			"\t\tbuf.Reset()",
+			"\t\tif err := "+fmt.Sprintf(render, args)+"; err != nil {",
+			"\t\t\tb.Fatal(err)",
+			"\t\t}",
+			"\t}",
+			"}",
+			"",
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func goCmd(dir string, args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
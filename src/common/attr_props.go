@@ -0,0 +1,212 @@
+package icons
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+var strictAttrs atomic.Bool
+
+// SetStrictAttrs sets whether rendering an icon fails with an *AttrError when
+// it is passed an attribute it doesn't recognize: a camel case name that is
+// not an alias of an svg attribute (e.g. a misspelled strokeWidht), or a
+// value of an unsupported type for size, color, stroke-width,
+// absoluteStrokeWidth, rotate, the flip and animation options, class, style
+// or any other attribute, or an unknown animation. Otherwise (the default)
+// such attributes are rendered as is or ignored, like templ does. It is meant
+// to be set once at startup, e.g. in development builds.
+func SetStrictAttrs(strict bool) {
+	strictAttrs.Store(strict)
+}
+
+// AttrError is the error of rendering an icon with an attribute it doesn't
+// recognize in strict mode, see SetStrictAttrs.
+type AttrError struct {
+	// Name of the attribute as passed to the icon
+	Name  string
+	Value any
+	Msg   string
+}
+
+func (e *AttrError) Error() string {
+	return fmt.Sprintf("icons: attribute %q: %s", e.Name, e.Msg)
+}
+
+// attrAlias returns the svg attribute name of a React style (camel case)
+// attribute name passed to an icon, e.g. stroke-width for strokeWidth, or the
+// name itself. absolute-stroke-width is an alias of absoluteStrokeWidth.
+func attrAlias(name string) string {
+	switch name {
+	case "className":
+		return "class"
+	case "absolute-stroke-width":
+		return "absoluteStrokeWidth"
+	case "strokeWidth":
+		return "stroke-width"
+	case "strokeLinecap":
+		return "stroke-linecap"
+	case "strokeLinejoin":
+		return "stroke-linejoin"
+	case "strokeMiterlimit":
+		return "stroke-miterlimit"
+	case "strokeDasharray":
+		return "stroke-dasharray"
+	case "strokeDashoffset":
+		return "stroke-dashoffset"
+	case "strokeOpacity":
+		return "stroke-opacity"
+	case "fillOpacity":
+		return "fill-opacity"
+	case "fillRule":
+		return "fill-rule"
+	case "clipRule":
+		return "clip-rule"
+	case "clipPath":
+		return "clip-path"
+	case "shapeRendering":
+		return "shape-rendering"
+	case "vectorEffect":
+		return "vector-effect"
+	case "pointerEvents":
+		return "pointer-events"
+	case "transformOrigin":
+		return "transform-origin"
+	case "tabIndex":
+		return "tabindex"
+	case "flipHorizontal":
+		return "flip-horizontal"
+	case "flipVertical":
+		return "flip-vertical"
+	case "animateDuration":
+		return "animate-duration"
+	case "animateAlways":
+		return "animate-always"
+	case "nonScalingStroke":
+		return "non-scaling-stroke"
+	}
+	return name
+}
+
+// attrName returns the normalized name of the attribute passed to an icon
+// with the given key, see attrAlias. skip is true for an alias of an
+// attribute set by the same map (e.g. strokeWidth along with stroke-width),
+// the attribute itself wins.
+func attrName[T attributes](attr T, key string) (name string, skip bool) {
+	name = attrAlias(key)
+	if name != key {
+		if _, ok := attr[name]; ok {
+			return name, true
+		}
+	}
+	return name, false
+}
+
+// Returns true if the name is a camel case svg attribute of the svg element
+func isSvgCamelCaseAttr(name string) bool {
+	switch name {
+	case "viewBox", "preserveAspectRatio", "baseProfile", "zoomAndPan":
+		return true
+	}
+	return false
+}
+
+// checkAttrName returns an *AttrError in strict mode if the normalized name
+// is a camel case name that is not an svg attribute.
+func checkAttrName(key string, name string, value any) *AttrError {
+	if !strictAttrs.Load() || isSvgCamelCaseAttr(name) {
+		return nil
+	}
+	for i := 0; i < len(name); i++ {
+		if 'A' <= name[i] && name[i] <= 'Z' {
+			return &AttrError{Name: key, Value: value, Msg: "unknown attribute"}
+		}
+	}
+	return nil
+}
+
+// attrStr returns the value of an attribute as a string: strings as is,
+// numbers formatted like strconv does, fmt.Stringer values (e.g. a color
+// type) with their String method and values of named string types as is. ok
+// is false for other types.
+func attrStr(value any) (s string, ok bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, true
+	case int:
+		return strconv.Itoa(v), true
+	case int8:
+		return strconv.FormatInt(int64(v), 10), true
+	case int16:
+		return strconv.FormatInt(int64(v), 10), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return stringKind(value)
+}
+
+// stringKind returns the value of a named string type (e.g. templ.SafeURL).
+func stringKind(value any) (string, bool) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return v.String(), true
+	}
+	return "", false
+}
+
+// propStr returns the value of a special attribute (size, color or
+// stroke-width) as a string, see attrStr, or defaultValue if it has an
+// unsupported type. In strict mode, unsupported types are an *AttrError.
+func propStr(key string, value any, defaultValue string) (string, error) {
+	if s, ok := attrStr(value); ok {
+		return s, nil
+	}
+	if value != nil && strictAttrs.Load() {
+		return "", &AttrError{Name: key, Value: value, Msg: fmt.Sprintf("unsupported value of type %T", value)}
+	}
+	return defaultValue, nil
+}
+
+// propBool returns the value of a boolean special attribute
+// (absoluteStrokeWidth). In strict mode, values other than
+// booleans and "true" or "false" are an *AttrError.
+func propBool(key string, value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if v == "true" || v == "false" || !strictAttrs.Load() {
+			return v == "true", nil
+		}
+	case nil:
+		return false, nil
+	default:
+		if !strictAttrs.Load() {
+			return false, nil
+		}
+	}
+	return false, &AttrError{Name: key, Value: value, Msg: fmt.Sprintf("invalid boolean %v", value)}
+}
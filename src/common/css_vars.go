@@ -0,0 +1,64 @@
+package icons
+
+import "sync/atomic"
+
+var cssVars atomic.Bool
+
+// SetCSSVars sets whether the icons are rendered with css custom properties
+// for their default size, stroke color and stroke width, so that they can be
+// themed with css (e.g. :root { --lucide-stroke-width: 1.5 }) without being
+// rendered again. The icons get a class per property (e.g. lucide-var-size)
+// applying it with the rules of ThemeCSS, rendered by Styles.
+//
+// The size, color and stroke width passed to an icon still override them, and
+// classes setting them (e.g. a width or stroke class) win over the rules.
+// Icons whose default differs from Lucide's (24px, currentColor and 2) keep
+// it. It is meant to be set once at startup.
+func SetCSSVars(enabled bool) {
+	cssVars.Store(enabled)
+}
+
+// ThemeCSS is the stylesheet applying the css custom properties of the icons
+// rendered with SetCSSVars(true). Its selectors have no specificity, so that
+// any class sizing or coloring the icons wins.
+const ThemeCSS = `:where(.lucide-var-size){width:var(--lucide-size,24px);height:var(--lucide-size,24px)}` +
+	`:where(.lucide-var-stroke){stroke:var(--lucide-stroke,currentColor)}` +
+	`:where(.lucide-var-stroke-width){stroke-width:var(--lucide-stroke-width,2)}`
+
+// Classes of the css custom properties of the root attributes of the icons,
+// applied when the icon has the default of ThemeCSS for all the attributes
+var cssVarClasses = [...]struct {
+	class    string
+	attrs    []string
+	fallback string
+}{
+	{"lucide-var-size", []string{"width", "height"}, "24"},
+	{"lucide-var-stroke", []string{"stroke"}, "currentColor"},
+	{"lucide-var-stroke-width", []string{"stroke-width"}, "2"},
+}
+
+// usesDefaultOpen returns true if the opening tag of an icon rendered with
+// attrs is the one of the icon with the default attributes.
+func usesDefaultOpen[T attributes](attrs []T) bool {
+	return noAttrs(attrs) && !cssVars.Load()
+}
+
+// appendCSSVarClasses appends the classes of the css variables mode (see
+// SetCSSVars) to list, for the root attributes of the icon that are not set by
+// the options and attributes passed to it (see resolveAttrs).
+func appendCSSVarClasses(list []className, defaults []attrValue) []className {
+	if !cssVars.Load() {
+		return list
+	}
+	for _, c := range cssVarClasses {
+		themed := true
+		for _, name := range c.attrs {
+			i := indexAttr(defaults, name)
+			themed = themed && i >= 0 && defaults[i].root && defaults[i].str == c.fallback
+		}
+		if themed {
+			list = append(list, className{name: c.class, enabled: true})
+		}
+	}
+	return list
+}
@@ -0,0 +1,109 @@
+package icons
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// DataURIOptions configures DataURI. Zero values keep the defaults of the
+// icon, like attributes that are not passed to a component.
+type DataURIOptions struct {
+	// Color of the icon (currentColor), e.g. "#2563eb"
+	Color string
+	// Width and height of the svg in pixels
+	Size int
+	// Stroke width in viewBox units, or in pixels with AbsoluteStrokeWidth
+	StrokeWidth float64
+	// Keep the stroke width constant whatever the size of the icon
+	AbsoluteStrokeWidth bool
+}
+
+// DataURISvg returns the svg of the shapes as a url encoded data URI, like
+// DataURI. The root node holds the attributes of the svg element.
+func DataURISvg(root Node, node IconNode, opts DataURIOptions) string {
+	attrs := make(rootAttrs, 0, len(root.Attrs))
+	for _, a := range root.Attrs {
+		attrs = append(attrs, rootAttr{a.Name, a.Value})
+	}
+	return svgDataURI(attrs, node, opts)
+}
+
+// svgDataURI writes the icon as an svg (with the root attributes overridden
+// by the options) and encodes it for a css url() or an img src.
+func svgDataURI(root rootAttrs, node IconNode, opts DataURIOptions) string {
+	var b strings.Builder
+	b.WriteString("<svg")
+	attr := func(name string, value string) {
+		b.WriteString(" " + name + "='" + html.EscapeString(value) + "'")
+	}
+	hasXmlns := false
+	for _, a := range root {
+		value := a.value
+		switch a.name {
+		case "xmlns":
+			hasXmlns = true
+		case "class":
+			continue
+		case "width", "height":
+			if opts.Size > 0 {
+				value = strconv.Itoa(opts.Size)
+			}
+		case "stroke-width":
+			if opts.StrokeWidth > 0 {
+				value = strconv.FormatFloat(opts.StrokeWidth, 'f', -1, 64)
+			}
+			if opts.AbsoluteStrokeWidth {
+				size := float64(opts.Size)
+				if size <= 0 {
+					size = parseNumberOr(root.get("width"), 24)
+				}
+				width := parseNumberOr(value, 2)
+				value = strconv.FormatFloat(width*root.viewBoxSide()/size, 'f', -1, 64)
+			}
+		}
+		if opts.Color != "" && value == "currentColor" {
+			value = opts.Color
+		}
+		attr(a.name, value)
+	}
+	if !hasXmlns {
+		// required for svg images
+		attr("xmlns", defaultXmlns)
+	}
+	b.WriteString(">")
+	for _, n := range node {
+		b.WriteString("<" + n.Tag)
+		for _, a := range n.Attrs {
+			value := a.Value
+			if opts.Color != "" && value == "currentColor" {
+				value = opts.Color
+			}
+			attr(a.Name, value)
+		}
+		b.WriteString("/>")
+	}
+	b.WriteString("</svg>")
+	return "data:image/svg+xml," + encodeDataURI(b.String())
+}
+
+// encodeDataURI percent-encodes the characters that are not allowed (or
+// special) in a url, keeping the svg readable. The svg must use single
+// quotes, so that the uri can be put in double quotes.
+func encodeDataURI(svg string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(svg); i++ {
+		c := svg[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			strings.IndexByte("-_.~!$&'()*+,;=:@/?", c) >= 0:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package icons
+
+import "strconv"
+
+const (
+	defaultXmlns          = "http://www.w3.org/2000/svg"
+	defaultWidth          = "24"
+	defaultHeight         = "24"
+	defaultViewBox        = "0 0 24 24"
+	defaultFill           = "none"
+	defaultStroke         = "currentColor"
+	defaultStrokeWidth    = "2"
+	defaultStrokeLinecap  = "round"
+	defaultStrokeLinejoin = "round"
+)
+
+// rootAttr is an attribute of the root svg element of an icon.
+type rootAttr struct {
+	name  string
+	value string
+}
+
+// rootAttrs are the attributes of the root svg element of an icon, in the
+// order of its svg file.
+type rootAttrs []rootAttr
+
+// Returns the value of the attribute or "" if the icon doesn't set it
+func (r rootAttrs) get(name string) string {
+	for _, a := range r {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// Returns the largest side of the viewBox of the icon, or of the icon if it
+// has no valid viewBox (24 if it has neither, like the Lucide icons). Icons
+// are rendered in a square box and the viewBox is fitted into it (the
+// default preserveAspectRatio), so its largest side sets the scale. It parses
+// the viewBox like parseNumbers, without allocating.
+func (r rootAttrs) viewBoxSide() float64 {
+	viewBox := r.get("viewBox")
+	n, side, valid := 0, 0.0, true
+	for start, i := 0, 0; i <= len(viewBox); i++ {
+		if i < len(viewBox) && viewBox[i] != ' ' && viewBox[i] != ',' {
+			continue
+		}
+		if i > start {
+			v, err := strconv.ParseFloat(viewBox[start:i], 64)
+			valid = valid && err == nil && (n < 2 || v > 0)
+			if n >= 2 {
+				side = max(side, v)
+			}
+			n++
+		}
+		start = i + 1
+	}
+	if valid && n == 4 {
+		return side
+	}
+	return max(parseNumberOr(r.get("width"), 24), parseNumberOr(r.get("height"), 24))
+}
+
+// Root attributes shared by the Lucide icons
+var defaultRootAttrs = rootAttrs{
+	{"xmlns", defaultXmlns},
+	{"width", defaultWidth},
+	{"height", defaultHeight},
+	{"viewBox", defaultViewBox},
+	{"fill", defaultFill},
+	{"stroke", defaultStroke},
+	{"stroke-width", defaultStrokeWidth},
+	{"stroke-linecap", defaultStrokeLinecap},
+	{"stroke-linejoin", defaultStrokeLinejoin},
+}
@@ -0,0 +1,29 @@
+package icons
+
+// Attr is an attribute of a Node.
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Node is a child element (path, circle, rect, ...) of an icon's svg.
+// Attributes are kept in the order of the svg file, as a slice rather than a
+// map so that the shapes of the icons are static data the linker can drop.
+type Node struct {
+	Tag   string
+	Attrs []Attr
+}
+
+// IconNode is the list of shapes of an icon, like Lucide's IconNode.
+// The shapes are shared and must not be modified.
+type IconNode []Node
+
+// Get returns the value of the attribute with the given name.
+func (n Node) Get(name string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,451 @@
+package icons
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PathPoint is a point in viewBox or pixel coordinates.
+type PathPoint struct {
+	X, Y float64
+}
+
+// PathSubpath is a flattened subpath, a polyline or polygon.
+type PathSubpath struct {
+	Points []PathPoint
+	Closed bool
+}
+
+// Number of arguments of each path command
+var pathArgCounts = map[byte]int{
+	'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'T': 2, 'A': 7, 'Z': 0,
+}
+
+// PathCommand is a single command of svg path data. Repeated arguments are
+// split into commands of their own, e.g. "M1 2 3 4" is M(1 2) L(3 4).
+type PathCommand struct {
+	Cmd  byte
+	Args []float64
+}
+
+// pathScanner reads the numbers of path data and points lists.
+type pathScanner struct {
+	s   string
+	pos int
+}
+
+func (n *pathScanner) skipSeparators() {
+	for n.pos < len(n.s) && strings.IndexByte(" \t\r\n,", n.s[n.pos]) >= 0 {
+		n.pos++
+	}
+}
+
+func (n *pathScanner) done() bool {
+	n.skipSeparators()
+	return n.pos >= len(n.s)
+}
+
+// flag reads an arc flag, which can be written without separators
+// ("a1 1 0 015 5").
+func (n *pathScanner) flag() (float64, error) {
+	n.skipSeparators()
+	if n.pos < len(n.s) && (n.s[n.pos] == '0' || n.s[n.pos] == '1') {
+		n.pos++
+		return float64(n.s[n.pos-1] - '0'), nil
+	}
+	return 0, fmt.Errorf("expected arc flag at offset %d", n.pos)
+}
+
+func (n *pathScanner) number() (float64, error) {
+	n.skipSeparators()
+	start := n.pos
+	digits := func() int {
+		count := 0
+		for n.pos < len(n.s) && n.s[n.pos] >= '0' && n.s[n.pos] <= '9' {
+			n.pos++
+			count++
+		}
+		return count
+	}
+	if n.pos < len(n.s) && (n.s[n.pos] == '+' || n.s[n.pos] == '-') {
+		n.pos++
+	}
+	count := digits()
+	if n.pos < len(n.s) && n.s[n.pos] == '.' {
+		n.pos++
+		count += digits()
+	}
+	if count == 0 {
+		n.pos = start
+		return 0, fmt.Errorf("expected number at offset %d", start)
+	}
+	if n.pos < len(n.s) && (n.s[n.pos] == 'e' || n.s[n.pos] == 'E') {
+		n.pos++
+		if n.pos < len(n.s) && (n.s[n.pos] == '+' || n.s[n.pos] == '-') {
+			n.pos++
+		}
+		if digits() == 0 {
+			return 0, fmt.Errorf("invalid exponent at offset %d", start)
+		}
+	}
+	return strconv.ParseFloat(n.s[start:n.pos], 64)
+}
+
+// ParsePathData parses the d attribute of a path. It is shared by the
+// rasterizer and the generator's optimizer.
+func ParsePathData(d string) ([]PathCommand, error) {
+	scanner := &pathScanner{s: d}
+	cmds := []PathCommand{}
+	for !scanner.done() {
+		letter := scanner.s[scanner.pos]
+		argCount, ok := pathArgCounts[upper(letter)]
+		if !ok {
+			return nil, fmt.Errorf("unexpected %q at offset %d of path data", letter, scanner.pos)
+		}
+		if len(cmds) == 0 && upper(letter) != 'M' {
+			return nil, fmt.Errorf("path data must start with a moveto")
+		}
+		scanner.pos++
+		cmd := letter
+		for {
+			args := make([]float64, argCount)
+			for i := range args {
+				var err error
+				if upper(cmd) == 'A' && (i == 3 || i == 4) {
+					args[i], err = scanner.flag()
+				} else {
+					args[i], err = scanner.number()
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			cmds = append(cmds, PathCommand{Cmd: cmd, Args: args})
+			if argCount == 0 || scanner.done() || !startsNumber(scanner.s[scanner.pos]) {
+				break
+			}
+			// implicit repetition, lineto after a moveto
+			if cmd == 'M' {
+				cmd = 'L'
+			} else if cmd == 'm' {
+				cmd = 'l'
+			}
+		}
+	}
+	return cmds, nil
+}
+
+// ParsePoints parses the numbers of the points attribute of a polyline or
+// polygon.
+func ParsePoints(points string) ([]float64, error) {
+	scanner := &pathScanner{s: points}
+	values := []float64{}
+	for !scanner.done() {
+		v, err := scanner.number()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func startsNumber(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.'
+}
+
+// AbsolutePath converts path commands to absolute M, L, C, Q, A and Z
+// commands.
+func AbsolutePath(cmds []PathCommand) []PathCommand {
+	abs := []PathCommand{}
+	var x, y, startX, startY float64
+	// last control point, for the smooth curve commands
+	var ctrlX, ctrlY float64
+	var prev byte
+	for _, c := range cmds {
+		cmd := upper(c.Cmd)
+		a := append([]float64{}, c.Args...)
+		if c.Cmd != cmd {
+			// relative to the current point
+			switch cmd {
+			case 'H':
+				a[0] += x
+			case 'V':
+				a[0] += y
+			case 'A':
+				a[5] += x
+				a[6] += y
+			default:
+				for i := 0; i+1 < len(a); i += 2 {
+					a[i] += x
+					a[i+1] += y
+				}
+			}
+		}
+		switch cmd {
+		case 'H':
+			cmd, a = 'L', []float64{a[0], y}
+		case 'V':
+			cmd, a = 'L', []float64{x, a[0]}
+		case 'S':
+			rx, ry := x, y
+			if prev == 'C' {
+				rx, ry = 2*x-ctrlX, 2*y-ctrlY
+			}
+			cmd, a = 'C', []float64{rx, ry, a[0], a[1], a[2], a[3]}
+		case 'T':
+			rx, ry := x, y
+			if prev == 'Q' {
+				rx, ry = 2*x-ctrlX, 2*y-ctrlY
+			}
+			cmd, a = 'Q', []float64{rx, ry, a[0], a[1]}
+		}
+		abs = append(abs, PathCommand{Cmd: cmd, Args: a})
+		switch cmd {
+		case 'Z':
+			x, y = startX, startY
+		case 'M':
+			x, y = a[0], a[1]
+			startX, startY = x, y
+		default:
+			x, y = a[len(a)-2], a[len(a)-1]
+		}
+		if cmd == 'C' {
+			ctrlX, ctrlY = a[2], a[3]
+		} else if cmd == 'Q' {
+			ctrlX, ctrlY = a[0], a[1]
+		}
+		prev = cmd
+	}
+	return abs
+}
+
+// flattener turns shapes into subpaths, with curves split into segments of
+// about maxLen viewBox units.
+type flattener struct {
+	maxLen float64
+	paths  []PathSubpath
+}
+
+func (f *flattener) moveTo(p PathPoint) {
+	f.paths = append(f.paths, PathSubpath{Points: []PathPoint{p}})
+}
+
+func (f *flattener) lineTo(p PathPoint) {
+	last := &f.paths[len(f.paths)-1]
+	if last.Closed {
+		// drawing after a closepath starts a new subpath at the same point
+		f.moveTo(last.Points[0])
+		last = &f.paths[len(f.paths)-1]
+	}
+	last.Points = append(last.Points, p)
+}
+
+func (f *flattener) close() {
+	f.paths[len(f.paths)-1].Closed = true
+}
+
+func (f *flattener) steps(length float64) int {
+	n := int(math.Ceil(length / f.maxLen))
+	return min(max(n, 2), 256)
+}
+
+func (f *flattener) cubicTo(p0, p1, p2, p3 PathPoint) {
+	n := f.steps(dist(p0, p1) + dist(p1, p2) + dist(p2, p3))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		f.lineTo(PathPoint{
+			u*u*u*p0.X + 3*u*u*t*p1.X + 3*u*t*t*p2.X + t*t*t*p3.X,
+			u*u*u*p0.Y + 3*u*u*t*p1.Y + 3*u*t*t*p2.Y + t*t*t*p3.Y,
+		})
+	}
+}
+
+func (f *flattener) quadTo(p0, p1, p2 PathPoint) {
+	n := f.steps(dist(p0, p1) + dist(p1, p2))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		f.lineTo(PathPoint{u*u*p0.X + 2*u*t*p1.X + t*t*p2.X, u*u*p0.Y + 2*u*t*p1.Y + t*t*p2.Y})
+	}
+}
+
+// arcTo follows the endpoint to center conversion of the svg spec.
+func (f *flattener) arcTo(p0 PathPoint, rx, ry, rotation float64, large, sweep bool, p PathPoint) {
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || p0 == p {
+		f.lineTo(p)
+		return
+	}
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (p0.X-p.X)/2, (p0.Y-p.Y)/2
+	x1, y1 := cos*dx+sin*dy, -sin*dx+cos*dy
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx, ry = rx*math.Sqrt(lambda), ry*math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		coef = -coef
+	}
+	cx1, cy1 := coef*rx*y1/ry, -coef*ry*x1/rx
+	cx := cos*cx1 - sin*cy1 + (p0.X+p.X)/2
+	cy := sin*cx1 + cos*cy1 + (p0.Y+p.Y)/2
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+	n := f.steps(math.Abs(delta) * math.Max(rx, ry))
+	for i := 1; i <= n; i++ {
+		a := theta + delta*float64(i)/float64(n)
+		if i == n {
+			f.lineTo(p)
+			break
+		}
+		f.lineTo(PathPoint{
+			cx + rx*math.Cos(a)*cos - ry*math.Sin(a)*sin,
+			cy + rx*math.Cos(a)*sin + ry*math.Sin(a)*cos,
+		})
+	}
+}
+
+func (f *flattener) ellipse(cx, cy, rx, ry float64) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	n := f.steps(2 * math.Pi * math.Max(rx, ry))
+	f.moveTo(PathPoint{cx + rx, cy})
+	for i := 1; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		f.lineTo(PathPoint{cx + rx*math.Cos(a), cy + ry*math.Sin(a)})
+	}
+	f.close()
+}
+
+// path flattens svg path data.
+func (f *flattener) path(d string) error {
+	cmds, err := ParsePathData(d)
+	if err != nil {
+		return err
+	}
+	var cur, start PathPoint
+	for _, c := range AbsolutePath(cmds) {
+		a := c.Args
+		switch c.Cmd {
+		case 'M':
+			start = PathPoint{a[0], a[1]}
+			f.moveTo(start)
+		case 'L':
+			f.lineTo(PathPoint{a[0], a[1]})
+		case 'C':
+			f.cubicTo(cur, PathPoint{a[0], a[1]}, PathPoint{a[2], a[3]}, PathPoint{a[4], a[5]})
+		case 'Q':
+			f.quadTo(cur, PathPoint{a[0], a[1]}, PathPoint{a[2], a[3]})
+		case 'A':
+			f.arcTo(cur, a[0], a[1], a[2], a[3] != 0, a[4] != 0, PathPoint{a[5], a[6]})
+		}
+		if c.Cmd == 'Z' {
+			f.close()
+			cur = start
+		} else {
+			cur = PathPoint{a[len(a)-2], a[len(a)-1]}
+		}
+	}
+	return nil
+}
+
+// FlattenShape returns the subpaths of a child element of an icon, with curves
+// split into segments of about maxLen viewBox units. It is shared by the
+// rasterizer and the generator's font outliner.
+func FlattenShape(n Node, maxLen float64) ([]PathSubpath, error) {
+	f := &flattener{maxLen: maxLen}
+	if err := f.shape(n); err != nil {
+		return nil, err
+	}
+	return f.paths, nil
+}
+
+// shape flattens a child element of an icon.
+func (f *flattener) shape(n Node) error {
+	num := func(name string) float64 {
+		value, _ := n.Get(name)
+		v, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return v
+	}
+	switch n.Tag {
+	case "path":
+		d, _ := n.Get("d")
+		return f.path(d)
+	case "line":
+		f.moveTo(PathPoint{num("x1"), num("y1")})
+		f.lineTo(PathPoint{num("x2"), num("y2")})
+	case "polyline", "polygon":
+		points, _ := n.Get("points")
+		coords, err := ParsePoints(points)
+		if err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(coords); i += 2 {
+			if i == 0 {
+				f.moveTo(PathPoint{coords[0], coords[1]})
+			} else {
+				f.lineTo(PathPoint{coords[i], coords[i+1]})
+			}
+		}
+		if n.Tag == "polygon" && len(coords) >= 2 {
+			f.close()
+		}
+	case "rect":
+		x, y, w, h := num("x"), num("y"), num("width"), num("height")
+		if w <= 0 || h <= 0 {
+			return nil
+		}
+		rx, ry := num("rx"), num("ry")
+		if _, ok := n.Get("ry"); !ok {
+			ry = rx
+		}
+		if _, ok := n.Get("rx"); !ok {
+			rx = ry
+		}
+		rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+		f.moveTo(PathPoint{x + rx, y})
+		f.lineTo(PathPoint{x + w - rx, y})
+		f.arcTo(PathPoint{x + w - rx, y}, rx, ry, 0, false, true, PathPoint{x + w, y + ry})
+		f.lineTo(PathPoint{x + w, y + h - ry})
+		f.arcTo(PathPoint{x + w, y + h - ry}, rx, ry, 0, false, true, PathPoint{x + w - rx, y + h})
+		f.lineTo(PathPoint{x + rx, y + h})
+		f.arcTo(PathPoint{x + rx, y + h}, rx, ry, 0, false, true, PathPoint{x, y + h - ry})
+		f.lineTo(PathPoint{x, y + ry})
+		f.arcTo(PathPoint{x, y + ry}, rx, ry, 0, false, true, PathPoint{x + rx, y})
+		f.close()
+	case "circle":
+		f.ellipse(num("cx"), num("cy"), num("r"), num("r"))
+	case "ellipse":
+		f.ellipse(num("cx"), num("cy"), num("rx"), num("ry"))
+	default:
+		return fmt.Errorf("unsupported element <%s>", n.Tag)
+	}
+	return nil
+}
+
+func dist(a, b PathPoint) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
@@ -0,0 +1,237 @@
+package icons
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RasterOptions configures Draw. Zero values keep the defaults of the icon,
+// like attributes that are not passed to a component.
+type RasterOptions struct {
+	// Color of the icon (currentColor), defaults to black
+	Color color.Color
+	// Stroke width in viewBox units, or in pixels with AbsoluteStrokeWidth
+	StrokeWidth float64
+	// Keep the stroke width constant whatever the size of the icon
+	AbsoluteStrokeWidth bool
+}
+
+// DrawSvg renders shapes over dst, scaled to fit in r, like Draw. The root
+// node holds the attributes of the svg element (viewBox, fill, stroke, ...).
+func DrawSvg(dst draw.Image, r image.Rectangle, root Node, node IconNode, opts RasterOptions) error {
+	attrs := make(rootAttrs, 0, len(root.Attrs))
+	for _, a := range root.Attrs {
+		attrs = append(attrs, rootAttr{a.Name, a.Value})
+	}
+	return drawIcon(dst, r, node, attrs, opts)
+}
+
+// drawIcon renders the shapes over dst, scaled to fit in r. Strokes always
+// have round caps and joins, like the Lucide icons.
+func drawIcon(dst draw.Image, r image.Rectangle, node IconNode, root rootAttrs, opts RasterOptions) error {
+	if r.Empty() {
+		return nil
+	}
+	current := opts.Color
+	if current == nil {
+		current = color.Black
+	}
+	viewBox := parseNumbers(root.get("viewBox"))
+	if len(viewBox) != 4 || viewBox[2] <= 0 || viewBox[3] <= 0 {
+		viewBox = []float64{0, 0, parseNumberOr(root.get("width"), 24), parseNumberOr(root.get("height"), 24)}
+	}
+	// viewBox to pixels, centered (xMidYMid meet)
+	scale := math.Min(float64(r.Dx())/viewBox[2], float64(r.Dy())/viewBox[3])
+	offset := PathPoint{
+		float64(r.Min.X) + (float64(r.Dx())-viewBox[2]*scale)/2 - viewBox[0]*scale,
+		float64(r.Min.Y) + (float64(r.Dy())-viewBox[3]*scale)/2 - viewBox[1]*scale,
+	}
+
+	// stroke width of the root element, in viewBox units
+	strokeWidth := parseNumberOr(root.get("stroke-width"), 1)
+	if opts.StrokeWidth > 0 {
+		strokeWidth = opts.StrokeWidth
+	}
+	if opts.AbsoluteStrokeWidth {
+		strokeWidth /= scale
+	}
+
+	for _, n := range node {
+		paths, err := FlattenShape(n, 1/scale)
+		if err != nil {
+			return err
+		}
+		for i := range paths {
+			for j, p := range paths[i].Points {
+				paths[i].Points[j] = PathPoint{offset.X + p.X*scale, offset.Y + p.Y*scale}
+			}
+		}
+		fill, _ := n.Get("fill")
+		if fill == "" {
+			fill = root.get("fill")
+		}
+		if c, ok := paint(fill, "black", current); ok && n.Tag != "line" {
+			drawMask(dst, r, c, fillMask(r, paths))
+		}
+		stroke, _ := n.Get("stroke")
+		if stroke == "" {
+			stroke = root.get("stroke")
+		}
+		width := strokeWidth
+		if value, ok := n.Get("stroke-width"); ok {
+			width = parseNumberOr(value, width)
+		}
+		if c, ok := paint(stroke, "none", current); ok && width > 0 {
+			drawMask(dst, r, c, strokeMask(r, paths, width*scale/2))
+		}
+	}
+	return nil
+}
+
+// paint returns the color of a fill or stroke value.
+func paint(value string, defaultValue string, current color.Color) (color.Color, bool) {
+	if value == "" {
+		value = defaultValue
+	}
+	switch value = strings.TrimSpace(value); value {
+	case "none", "transparent":
+		return nil, false
+	case "black":
+		return color.Black, true
+	case "white":
+		return color.White, true
+	}
+	if hex := strings.TrimPrefix(value, "#"); hex != value {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil && len(hex) == 6 {
+			return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}, true
+		}
+	}
+	// currentColor and the colors that are not supported
+	return current, true
+}
+
+func drawMask(dst draw.Image, r image.Rectangle, c color.Color, mask *image.Alpha) {
+	draw.DrawMask(dst, r, image.NewUniform(c), image.Point{}, mask, r.Min, draw.Over)
+}
+
+// strokeMask covers the pixels within hw (half the stroke width) of the
+// subpaths, which gives round caps and joins.
+func strokeMask(r image.Rectangle, paths []PathSubpath, hw float64) *image.Alpha {
+	mask := image.NewAlpha(r)
+	// strokes thinner than a pixel are lighter rather than thinner
+	weight := math.Min(1, 2*hw)
+	segment := func(a, b PathPoint) {
+		minX := max(int(math.Floor(math.Min(a.X, b.X)-hw-1)), r.Min.X)
+		maxX := min(int(math.Ceil(math.Max(a.X, b.X)+hw+1)), r.Max.X)
+		minY := max(int(math.Floor(math.Min(a.Y, b.Y)-hw-1)), r.Min.Y)
+		maxY := min(int(math.Ceil(math.Max(a.Y, b.Y)+hw+1)), r.Max.Y)
+		for y := minY; y < maxY; y++ {
+			for x := minX; x < maxX; x++ {
+				d := distToSegment(PathPoint{float64(x) + .5, float64(y) + .5}, a, b)
+				coverage := math.Max(0, math.Min(1, hw+.5-d)) * weight
+				if alpha := uint8(coverage*255 + .5); alpha > mask.AlphaAt(x, y).A {
+					mask.SetAlpha(x, y, color.Alpha{alpha})
+				}
+			}
+		}
+	}
+	for _, p := range paths {
+		points := p.Points
+		if p.Closed {
+			points = append(points[:len(points):len(points)], points[0])
+		}
+		if len(points) == 1 {
+			segment(points[0], points[0])
+		}
+		for i := 1; i < len(points); i++ {
+			segment(points[i-1], points[i])
+		}
+	}
+	return mask
+}
+
+// fillMask covers the inside of the subpaths (nonzero rule), sampling 4x4
+// points per pixel.
+func fillMask(r image.Rectangle, paths []PathSubpath) *image.Alpha {
+	mask := image.NewAlpha(r)
+	const samples = 4
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range paths {
+		for _, pt := range p.Points {
+			minX, minY = math.Min(minX, pt.X), math.Min(minY, pt.Y)
+			maxX, maxY = math.Max(maxX, pt.X), math.Max(maxY, pt.Y)
+		}
+	}
+	if math.IsInf(minX, 0) {
+		return mask
+	}
+	for y := max(int(minY), r.Min.Y); y < min(int(maxY)+1, r.Max.Y); y++ {
+		for x := max(int(minX), r.Min.X); x < min(int(maxX)+1, r.Max.X); x++ {
+			inside := 0
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					pt := PathPoint{float64(x) + (float64(sx)+.5)/samples, float64(y) + (float64(sy)+.5)/samples}
+					if winding(pt, paths) != 0 {
+						inside++
+					}
+				}
+			}
+			mask.SetAlpha(x, y, color.Alpha{uint8(inside * 255 / (samples * samples))})
+		}
+	}
+	return mask
+}
+
+// winding returns the winding number of the subpaths (all implicitly closed)
+// around the point.
+func winding(pt PathPoint, paths []PathSubpath) int {
+	w := 0
+	for _, p := range paths {
+		for i := range p.Points {
+			a, b := p.Points[i], p.Points[(i+1)%len(p.Points)]
+			side := (b.X-a.X)*(pt.Y-a.Y) - (pt.X-a.X)*(b.Y-a.Y)
+			if a.Y <= pt.Y && b.Y > pt.Y && side > 0 {
+				w++
+			} else if b.Y <= pt.Y && a.Y > pt.Y && side < 0 {
+				w--
+			}
+		}
+	}
+	return w
+}
+
+func distToSegment(p, a, b PathPoint) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lenSq := dx*dx + dy*dy
+	t := 0.0
+	if lenSq > 0 {
+		t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/lenSq))
+	}
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+func parseNumbers(value string) []float64 {
+	numbers := []float64{}
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }) {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil
+		}
+		numbers = append(numbers, v)
+	}
+	return numbers
+}
+
+func parseNumberOr(value string, defaultValue float64) float64 {
+	if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+		return v
+	}
+	return defaultValue
+}
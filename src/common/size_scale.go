@@ -0,0 +1,71 @@
+package icons
+
+import (
+	"context"
+	"maps"
+	"sync/atomic"
+)
+
+// Size is a named size of the icons, see SizeScale.
+type Size struct {
+	// Width and height of the icon in pixels
+	Px float64
+	// Stroke width of the icon at this size, 0 keeps the stroke width of the
+	// icon. A stroke-width passed to the icon wins.
+	StrokeWidth float64
+}
+
+// SizeScale maps the names of sizes (e.g. "sm") to sizes, so that the size
+// attribute of the icons can be a name of the design system rather than a
+// number of pixels.
+type SizeScale map[string]Size
+
+// DefaultSizeScale returns the default size scale, from xs (12px) to 2xl
+// (40px), keeping the stroke width of the icons.
+func DefaultSizeScale() SizeScale {
+	return SizeScale{
+		"xs":  {Px: 12},
+		"sm":  {Px: 16},
+		"md":  {Px: 20},
+		"lg":  {Px: 24},
+		"xl":  {Px: 32},
+		"2xl": {Px: 40},
+	}
+}
+
+var defaultSizeScale = DefaultSizeScale()
+
+var sizeScale atomic.Pointer[SizeScale]
+
+// SetSizeScale sets the size scale of the icons, replacing the default one.
+// It is meant to be set once at startup, see WithSizeScale to set it per
+// request.
+func SetSizeScale(scale SizeScale) {
+	scale = maps.Clone(scale)
+	sizeScale.Store(&scale)
+}
+
+// Returns the size scale set by SetSizeScale, or the default one
+func currentSizeScale() SizeScale {
+	if scale := sizeScale.Load(); scale != nil {
+		return *scale
+	}
+	return defaultSizeScale
+}
+
+type sizeScaleKey struct{}
+
+// WithSizeScale returns a context rendering the icons with the size scale,
+// overriding the one set by SetSizeScale. Only the components rendered with
+// a context (templ) use it.
+func WithSizeScale(ctx context.Context, scale SizeScale) context.Context {
+	return context.WithValue(ctx, sizeScaleKey{}, maps.Clone(scale))
+}
+
+// Returns the size scale of the context, see WithSizeScale
+func sizeScaleFrom(ctx context.Context) SizeScale {
+	if scale, ok := ctx.Value(sizeScaleKey{}).(SizeScale); ok {
+		return scale
+	}
+	return currentSizeScale()
+}
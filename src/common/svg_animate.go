@@ -0,0 +1,82 @@
+package icons
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AnimationCSS is the stylesheet of the animations of the icons (the animate
+// option). The duration of an animation is set by the
+// --lucide-animation-duration custom property (the animate-duration option),
+// and animations stop when the user prefers reduced motion unless the
+// animate-always option is set.
+const AnimationCSS = `@keyframes lucide-spin{to{transform:rotate(360deg)}}` +
+	`@keyframes lucide-pulse{50%{opacity:.5}}` +
+	`@keyframes lucide-bounce{0%,100%{transform:translateY(-25%);animation-timing-function:cubic-bezier(.8,0,1,1)}50%{transform:none;animation-timing-function:cubic-bezier(0,0,.2,1)}}` +
+	`.lucide-animate-spin{animation:lucide-spin var(--lucide-animation-duration,1s) linear infinite}` +
+	`.lucide-animate-pulse{animation:lucide-pulse var(--lucide-animation-duration,2s) cubic-bezier(.4,0,.6,1) infinite}` +
+	`.lucide-animate-bounce{animation:lucide-bounce var(--lucide-animation-duration,1s) infinite}` +
+	`@media (prefers-reduced-motion:reduce){.lucide-animate:not(.lucide-animate-always){animation:none}}`
+
+// Returns the class of the animation preset, or "" if there is no such preset
+func animationClass(animation string) string {
+	switch animation {
+	case "spin":
+		return "lucide-animate-spin"
+	case "pulse":
+		return "lucide-animate-pulse"
+	case "bounce":
+		return "lucide-animate-bounce"
+	}
+	return ""
+}
+
+// appendAnimationClasses appends the classes of the animate and
+// animate-always options to list. In strict mode, unknown presets are an
+// *AttrError, otherwise they are ignored.
+func appendAnimationClasses[T attributes](list []className, attrs []T) ([]className, error) {
+	var animate, always propValue
+	for _, attr := range attrs {
+		for key, value := range attr {
+			name, skip := attrName(attr, key)
+			if skip {
+				continue
+			}
+			switch name {
+			case "animate":
+				animate = propValue{key, value}
+			case "animate-always":
+				always = propValue{key, value}
+			}
+		}
+	}
+	animation, err := propStr(animate.key, animate.value, "")
+	if err != nil || animation == "" {
+		return list, err
+	}
+	class := animationClass(animation)
+	if class == "" {
+		if strictAttrs.Load() {
+			return nil, &AttrError{Name: animate.key, Value: animate.value, Msg: fmt.Sprintf("unknown animation %q", animation)}
+		}
+		return list, nil
+	}
+	alwaysBool, err := propBool(always.key, always.value)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, className{name: "lucide-animate", enabled: true}, className{name: class, enabled: true})
+	if alwaysBool {
+		list = append(list, className{name: "lucide-animate-always", enabled: true})
+	}
+	return list, nil
+}
+
+// animationDuration returns the css duration of the animate-duration option:
+// numbers are milliseconds, other values are used as is (e.g. "2s").
+func animationDuration(duration string) string {
+	if _, err := strconv.ParseFloat(duration, 64); err == nil {
+		return duration + "ms"
+	}
+	return duration
+}
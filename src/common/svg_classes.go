@@ -0,0 +1,125 @@
+package icons
+
+import "fmt"
+
+// className is a class of the svg element. Conditional classes (e.g. a
+// map[string]bool) can disable a class, the last occurrence of a class wins.
+type className struct {
+	name    string
+	enabled bool
+}
+
+// classFunc appends the classes of the value of a class attribute to list,
+// see appendClassNames. ok is false for values of an unsupported type.
+type classFunc func(list []className, value any) (result []className, ok bool)
+
+// appendClassList appends the space separated classes to list.
+func appendClassList(list []className, class string, enabled bool) []className {
+	start := 0
+	for i := 0; i <= len(class); i++ {
+		if i == len(class) || class[i] == ' ' {
+			if i > start {
+				list = append(list, className{name: class[start:i], enabled: enabled})
+			}
+			start = i + 1
+		}
+	}
+	return list
+}
+
+// appendClassNames appends the classes of the value of a class attribute:
+// strings of space separated classes, []string, map[string]bool of
+// conditional classes, and values with a ClassName (like templ.CSSClass) or a
+// String method.
+func appendClassNames(list []className, value any) ([]className, bool) {
+	switch v := value.(type) {
+	case nil:
+		return list, true
+	case string:
+		return appendClassList(list, v, true), true
+	case []string:
+		for _, class := range v {
+			list = appendClassList(list, class, true)
+		}
+		return list, true
+	case map[string]bool:
+		for class, enabled := range v {
+			list = appendClassList(list, class, enabled)
+		}
+		return list, true
+	case interface{ ClassName() string }:
+		return appendClassList(list, v.ClassName(), true), true
+	case fmt.Stringer:
+		return appendClassList(list, v.String(), true), true
+	}
+	return list, false
+}
+
+// resolveClasses appends the classes of the svg element to list (e.g. the
+// classes of SetCSSVars): the classes of the icon and of its animation merged
+// with the ones passed to it (as class or className), sorted and without
+// duplicates nor disabled classes. In strict mode, class
+// values of an unsupported type are an *AttrError, otherwise they are ignored.
+func resolveClasses[T attributes](list []className, class string, attrs []T, classes classFunc) ([]className, error) {
+	list = appendClassList(list, class, true)
+	list, err := appendAnimationClasses(list, attrs)
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range attrs {
+		key := "class"
+		value, ok := attr[key]
+		if !ok {
+			key = "className"
+			value = attr[key]
+		}
+		if s, ok := value.(string); ok {
+			list = appendClassList(list, s, true)
+			continue
+		}
+		// other types are resolved on the heap, passing list to classes
+		// would move it off the stack for every icon
+		more, ok := classes(nil, value)
+		if !ok && strictAttrs.Load() {
+			return nil, &AttrError{Name: key, Value: value, Msg: fmt.Sprintf("unsupported class of type %T", value)}
+		}
+		list = append(list, more...)
+	}
+	// stable, so that the last occurrence of a class stays last
+	for i := 1; i < len(list); i++ {
+		for j := i; j > 0 && list[j].name < list[j-1].name; j-- {
+			list[j], list[j-1] = list[j-1], list[j]
+		}
+	}
+	n := 0
+	for i, c := range list {
+		if i+1 < len(list) && list[i+1].name == c.name || !c.enabled {
+			continue
+		}
+		list[n] = c
+		n++
+	}
+	return list[:n], nil
+}
+
+// hasClasses returns true if a class or an animation is passed to the icon.
+func hasClasses[T attributes](attrs []T) bool {
+	for _, attr := range attrs {
+		if attr["class"] != nil || attr["className"] != nil || attr["animate"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// writeClasses writes the class attribute with the resolved classes.
+func writeClasses(a *attrWriter, classes []className) {
+	a.write(` class="`)
+	for i, c := range classes {
+		if i > 0 {
+			a.write(" ")
+		}
+		a.escape(c.name)
+	}
+	a.write(`"`)
+}
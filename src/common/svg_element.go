@@ -0,0 +1,245 @@
+package icons
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// Attributes and classes of the svg element are resolved in arrays on the
+// stack, falling back to the heap for icons given more of them than this.
+const (
+	maxStackDefaultAttrs = 16
+	maxStackAttrs        = 32
+	maxStackClasses      = 16
+)
+
+// svgIcon is the root svg element of an icon. Its opening tag is rendered
+// with the default attributes when generating the icon, and written as is
+// when no attributes are passed to the icon.
+type svgIcon struct {
+	root rootAttrs
+	// classes of the icon, sorted and without duplicates
+	class string
+	// opening tag with the default attributes, as written by writeSvgOpen
+	open string
+	// markup of the shapes, for flavors rendering the whole element
+	content string
+}
+
+// valueFunc converts an attribute value of a type of a flavor (e.g.
+// templ.KeyValue) to a value written by attrWriter.attr. ok is false for
+// values of other types.
+type valueFunc func(value any) (v any, ok bool)
+
+// attrWriter writes the attributes of an element, keeping the first error.
+// Values of the types of the flavor are converted with values.
+type attrWriter struct {
+	w      io.Writer
+	values valueFunc
+	err    error
+}
+
+func (a *attrWriter) write(s string) {
+	if a.err == nil {
+		_, a.err = io.WriteString(a.w, s)
+	}
+}
+
+// escape writes the string escaped like html.EscapeString, skipping the
+// (comparatively slow) escaping of strings without special characters.
+func (a *attrWriter) escape(s string) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '&', '\'', '<', '>', '"':
+			a.write(html.EscapeString(s))
+			return
+		}
+	}
+	a.write(s)
+}
+
+func (a *attrWriter) str(name string, value string) {
+	a.write(" ")
+	a.escape(name)
+	a.write(`="`)
+	a.escape(value)
+	a.write(`"`)
+}
+
+func (a *attrWriter) flag(name string) {
+	a.write(" ")
+	a.escape(name)
+}
+
+// attr writes the attribute the way templ.RenderAttributes does: strings as
+// name="value" and true booleans as a bare name. Numbers and fmt.Stringer
+// values are written like strings (see attrStr), and values of the types of
+// the flavor are converted first. Other values are skipped, or are an
+// *AttrError in strict mode.
+func (a *attrWriter) attr(attr attrValue) {
+	if attr.computed {
+		a.str(attr.name, attr.str)
+		return
+	}
+	switch value := attr.value.(type) {
+	case nil:
+	case *string:
+		if value != nil {
+			a.str(attr.name, *value)
+		}
+	case bool:
+		if value {
+			a.flag(attr.name)
+		}
+	case *bool:
+		if value != nil && *value {
+			a.flag(attr.name)
+		}
+	case func() bool:
+		if value() {
+			a.flag(attr.name)
+		}
+	default:
+		if s, ok := attrStr(value); ok {
+			a.str(attr.name, s)
+		} else if v, ok := a.convert(value); ok {
+			a.attr(attrValue{name: attr.name, value: v})
+		} else if strictAttrs.Load() && a.err == nil {
+			a.err = &AttrError{Name: attr.name, Value: value, Msg: fmt.Sprintf("unsupported value of type %T", value)}
+		}
+	}
+}
+
+func (a *attrWriter) convert(value any) (any, bool) {
+	if a.values == nil {
+		return nil, false
+	}
+	return a.values(value)
+}
+
+// writeSvgOpen writes the opening svg tag of an icon, with the attributes in
+// the order of resolveAttrs: the root attributes of the icon, its class and
+// the other attributes passed to it sorted by name. The classes passed to the
+// icon are read with classes, see resolveClasses, the values of the other
+// attributes are converted with values (nil for none) and named sizes are
+// resolved with scale.
+func writeSvgOpen[T attributes](w io.Writer, icon *svgIcon, attrs []T, classes classFunc, values valueFunc, scale SizeScale) error {
+	if usesDefaultOpen(attrs) {
+		_, err := io.WriteString(w, icon.open)
+		return err
+	}
+	var defaultsStack [maxStackDefaultAttrs]attrValue
+	var extraStack [maxStackAttrs]attrValue
+	defaults, extra, err := resolveAttrs(defaultsStack[:0], extraStack[:0], icon.root, attrs, scale)
+	if err != nil {
+		return err
+	}
+	var classStack [maxStackClasses]className
+	list := appendCSSVarClasses(classStack[:0], defaults)
+	customClasses := len(list) > 0 || hasClasses(attrs)
+	if customClasses {
+		if list, err = resolveClasses(list, icon.class, attrs, classes); err != nil {
+			return err
+		}
+	}
+	a := attrWriter{w: w, values: values}
+	a.write("<svg")
+	for _, attr := range defaults {
+		a.attr(attr)
+	}
+	if customClasses {
+		writeClasses(&a, list)
+	} else {
+		a.str("class", icon.class)
+	}
+	for _, attr := range extra {
+		a.attr(attr)
+	}
+	a.write(">")
+	return a.err
+}
+
+// Render writes the svg element of the icon with the default attributes.
+func (i *svgIcon) Render(w io.Writer) error {
+	return svgElement[map[string]any]{icon: i}.Render(w)
+}
+
+// svgElement renders the whole svg element of an icon with the attributes
+// passed to it, as a gomponents node.
+type svgElement[T attributes] struct {
+	icon  *svgIcon
+	attrs []T
+}
+
+// writeSvgContent writes the shapes of an icon, with a non scaling stroke if
+// the non-scaling-stroke option (or absoluteStrokeWidth without a numeric
+// size) is passed to it, see resolveProps.
+func writeSvgContent[T attributes](w io.Writer, icon *svgIcon, attrs []T, scale SizeScale) error {
+	if hasStrokeOptions(attrs) {
+		// invalid options are reported when writing the opening tag
+		if props, err := resolveProps(icon.root, attrs, scale); err == nil && props.nonScalingStroke {
+			return writeNonScalingStroke(w, icon.content)
+		}
+	}
+	_, err := io.WriteString(w, icon.content)
+	return err
+}
+
+// hasStrokeOptions returns true if an option changing the stroke of the
+// shapes (non-scaling-stroke or absoluteStrokeWidth) is passed to the icon.
+func hasStrokeOptions[T attributes](attrs []T) bool {
+	for _, attr := range attrs {
+		for key := range attr {
+			switch attrAlias(key) {
+			case "non-scaling-stroke", "absoluteStrokeWidth":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeNonScalingStroke writes the markup of the shapes of an icon with
+// vector-effect="non-scaling-stroke" on each element, so that their stroke
+// width is in pixels whatever the size of the icon.
+func writeNonScalingStroke(w io.Writer, content string) error {
+	a := attrWriter{w: w}
+	start := 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != '<' || i+1 < len(content) && content[i+1] == '/' {
+			continue
+		}
+		// end of the tag name
+		i++
+		for i < len(content) && content[i] != ' ' && content[i] != '>' && content[i] != '/' {
+			i++
+		}
+		a.write(content[start:i])
+		a.str("vector-effect", "non-scaling-stroke")
+		start = i
+	}
+	a.write(content[start:])
+	return a.err
+}
+
+// svgNode returns the svg element of an icon rendered with the attributes
+// passed to it. Without attributes, the icon itself is returned so that
+// rendering it doesn't allocate.
+func svgNode[T attributes](icon *svgIcon, attrs []T) interface{ Render(io.Writer) error } {
+	if noAttrs(attrs) {
+		return icon
+	}
+	return svgElement[T]{icon: icon, attrs: attrs}
+}
+
+func (e svgElement[T]) Render(w io.Writer) error {
+	if err := writeSvgOpen(w, e.icon, e.attrs, appendClassNames, nil, currentSizeScale()); err != nil {
+		return err
+	}
+	if err := writeSvgContent(w, e.icon, e.attrs, currentSizeScale()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</svg>")
+	return err
+}
@@ -0,0 +1,253 @@
+package icons
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Declarations of the style attribute are merged in an array on the stack,
+// falling back to the heap for more of them than this.
+const maxStackStyleDecls = 16
+
+// styleDecl is a declaration of the style attribute of the svg element.
+type styleDecl struct {
+	property string
+	value    string
+}
+
+// setStyleDecl replaces the declaration of the same property in the list,
+// keeping its position, or appends the declaration to the list.
+func setStyleDecl(list []styleDecl, decl styleDecl) []styleDecl {
+	for i := range list {
+		if strings.EqualFold(list[i].property, decl.property) {
+			list[i] = decl
+			return list
+		}
+	}
+	return append(list, decl)
+}
+
+// parseStyle appends the declarations of a css declaration list (e.g.
+// "color: red; opacity: .5") to list. Semicolons in parentheses and quotes,
+// like in url("data:...;base64,..."), don't end a declaration.
+func parseStyle(list []styleDecl, style string) []styleDecl {
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i <= len(style); i++ {
+		if i < len(style) {
+			c := style[i]
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')':
+				if depth > 0 {
+					depth--
+				}
+				continue
+			case c != ';' || depth > 0:
+				continue
+			}
+		}
+		if property, value, ok := strings.Cut(style[start:i], ":"); ok {
+			property, value = strings.TrimSpace(property), strings.TrimSpace(value)
+			if property != "" && value != "" {
+				list = setStyleDecl(list, styleDecl{property: property, value: value})
+			}
+		}
+		start = i + 1
+	}
+	return list
+}
+
+// appendStyleDecls appends the declarations of the value of a style
+// attribute: a css declaration list (a string, or a value of a named string
+// type like templ.SafeCSS), or a map[string]string or map[string]any of
+// properties (added in the order of their names). ok is false for values of
+// an unsupported type.
+func appendStyleDecls(list []styleDecl, value any) ([]styleDecl, bool) {
+	switch v := value.(type) {
+	case nil:
+		return list, true
+	case map[string]string:
+		properties := make([]string, 0, len(v))
+		for property := range v {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			list = setStyleDecl(list, styleDecl{property: property, value: v[property]})
+		}
+		return list, true
+	case map[string]any:
+		properties := make([]string, 0, len(v))
+		for property := range v {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			if s, ok := attrStr(v[property]); ok {
+				list = setStyleDecl(list, styleDecl{property: property, value: s})
+			}
+		}
+		return list, true
+	}
+	if style, ok := styleStr(value); ok {
+		return parseStyle(list, style), true
+	}
+	return list, false
+}
+
+// styleStr returns the value of a style attribute given as a string or as a
+// named string type (e.g. templ.SafeCSS).
+func styleStr(value any) (string, bool) {
+	if style, ok := value.(string); ok {
+		return style, true
+	}
+	return stringKind(value)
+}
+
+// isCSSNumber returns true if value is a css number, optionally followed by
+// one of the units (e.g. 90 or 0.25turn), so that it is written into the style
+// without adding other declarations to it.
+func isCSSNumber(value string, units ...string) bool {
+	for _, unit := range units {
+		if len(value) > len(unit) && strings.EqualFold(value[len(value)-len(unit):], unit) {
+			value = value[:len(value)-len(unit)]
+			break
+		}
+	}
+	i := 0
+	digits := func() int {
+		start := i
+		for i < len(value) && value[i] >= '0' && value[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+	sign := func() {
+		if i < len(value) && (value[i] == '+' || value[i] == '-') {
+			i++
+		}
+	}
+	// [+-]? (digits | digits? . digits) ([eE] [+-]? digits)?
+	sign()
+	n := digits()
+	if i < len(value) && value[i] == '.' {
+		i++
+		if digits() == 0 {
+			return false
+		}
+		n++
+	}
+	if n == 0 {
+		return false
+	}
+	if i < len(value) && (value[i] == 'e' || value[i] == 'E') {
+		i++
+		sign()
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(value)
+}
+
+// cssNumberProp returns the value s of an option written into the style if
+// it is a css number with one of the units, see isCSSNumber. Other values are
+// an *AttrError in strict mode, otherwise they are ignored.
+func cssNumberProp(p propValue, s string, kind string, units ...string) (string, error) {
+	if s == "" || isCSSNumber(s, units...) {
+		return s, nil
+	}
+	if strictAttrs.Load() {
+		return "", &AttrError{Name: p.key, Value: p.value, Msg: fmt.Sprintf("invalid %s %q", kind, s)}
+	}
+	return "", nil
+}
+
+// styleTransform returns the css transform rotating the icon by rotate
+// (degrees, or an angle with its unit such as 0.25turn) and flipping it, or ""
+// if it is neither rotated nor flipped.
+func styleTransform(rotate string, flipHorizontal bool, flipVertical bool) string {
+	transform := ""
+	if rotate != "" {
+		if _, err := strconv.ParseFloat(rotate, 64); err == nil {
+			rotate += "deg"
+		}
+		transform = "rotate(" + rotate + ")"
+	}
+	if flipHorizontal {
+		transform += " scaleX(-1)"
+	}
+	if flipVertical {
+		transform += " scaleY(-1)"
+	}
+	return strings.TrimPrefix(transform, " ")
+}
+
+// resolveStyle returns the style attribute of the svg element: the style of
+// the icon merged with the styles passed to it, the last declaration of a
+// property winning, then the declarations set by the options of the icon
+// (decls) and the transform of the rotate and flip options, added after the
+// transform of the styles. A single style string is returned as is when the
+// options don't change it. ok is false if the element has no style. In
+// strict mode, style values of an unsupported type are an *AttrError,
+// otherwise they are ignored.
+func resolveStyle[T attributes](root string, attrs []T, decls []styleDecl, transform string) (style string, ok bool, err error) {
+	sources := 0
+	if root != "" {
+		sources, style = 1, root
+	}
+	for _, attr := range attrs {
+		if value := attr["style"]; value != nil {
+			sources++
+			style, _ = styleStr(value)
+		}
+	}
+	if transform == "" && len(decls) == 0 && sources <= 1 && (sources == 0 || style != "") {
+		return style, sources == 1, nil
+	}
+
+	var stack [maxStackStyleDecls]styleDecl
+	list := parseStyle(stack[:0], root)
+	for _, attr := range attrs {
+		value := attr["style"]
+		if list, ok = appendStyleDecls(list, value); !ok && strictAttrs.Load() {
+			return "", false, &AttrError{Name: "style", Value: value, Msg: fmt.Sprintf("unsupported style of type %T", value)}
+		}
+	}
+	for _, decl := range decls {
+		list = setStyleDecl(list, decl)
+	}
+	if transform != "" {
+		decl := styleDecl{property: "transform", value: transform}
+		for _, d := range list {
+			if strings.EqualFold(d.property, "transform") && d.value != "none" {
+				decl.value = d.value + " " + transform
+			}
+		}
+		list = setStyleDecl(list, decl)
+	}
+	var b strings.Builder
+	for i, d := range list {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(d.property)
+		b.WriteString(": ")
+		b.WriteString(d.value)
+	}
+	return b.String(), true, nil
+}
@@ -0,0 +1,280 @@
+package icons
+
+import "strconv"
+
+// attributes is satisfied by the attribute map of every output flavor
+// (e.g. templ.Attributes), so the same merging rules apply to all of them.
+type attributes interface {
+	~map[string]any
+}
+
+// noAttrs returns true if no attribute is passed to the icon.
+func noAttrs[T attributes](attrs []T) bool {
+	for _, attr := range attrs {
+		if len(attr) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// attrValue is a resolved attribute of the svg element. Values computed by
+// the runtime are kept in str, so that resolving them doesn't allocate.
+type attrValue struct {
+	name     string
+	value    any
+	str      string
+	computed bool
+	// root attribute of the icon, not set by the attributes passed to it
+	root bool
+}
+
+// setAttr replaces the attribute of the same name in the list, keeping its
+// position, or appends the attribute to the list.
+func setAttr(list []attrValue, attr attrValue) []attrValue {
+	if i := indexAttr(list, attr.name); i >= 0 {
+		list[i] = attr
+		return list
+	}
+	return append(list, attr)
+}
+
+// Returns the index of the attribute with the given name, or -1
+func indexAttr(list []attrValue, name string) int {
+	for i := range list {
+		if list[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertAttr sets the attribute in the list sorted by name, replacing the
+// attribute of the same name.
+func insertAttr(list []attrValue, attr attrValue) []attrValue {
+	i := len(list)
+	for i > 0 && list[i-1].name > attr.name {
+		i--
+	}
+	if i > 0 && list[i-1].name == attr.name {
+		list[i-1] = attr
+		return list
+	}
+	list = append(list, attrValue{})
+	copy(list[i+1:], list[i:])
+	list[i] = attr
+	return list
+}
+
+// propValue is a special attribute passed to an icon, with the key it was
+// passed with.
+type propValue struct {
+	key   string
+	value any
+}
+
+// iconProps are the options passed to an icon along with its attributes
+// (size, color, rotate, ...), resolved. Options not passed are empty.
+type iconProps struct {
+	size             string
+	strokeWidth      string
+	color            string
+	rotate           string
+	flipHorizontal   bool
+	flipVertical     bool
+	animateDuration  string
+	nonScalingStroke bool
+	// stroke width set by absoluteStrokeWidth, formatted in strokeWidth by
+	// resolveAttrs so that resolving the options doesn't allocate
+	absoluteStrokeWidth float64
+}
+
+// resolveProps resolves the options passed to an icon. Named sizes (e.g.
+// "sm") are resolved with scale, see SizeScale. absoluteStrokeWidth keeps the
+// stroke width of the icon in pixels: it is scaled by the largest side of the
+// viewBox of the icon over its size, or the shapes have a non scaling stroke
+// when the size is not a number (e.g. when it is set by css).
+func resolveProps[T attributes](root rootAttrs, attrs []T, scale SizeScale) (iconProps, error) {
+	var size, strokeWidth, color, absolute, rotate, flipHorizontal, flipVertical, duration, nonScaling propValue
+	for _, attr := range attrs {
+		for key, value := range attr {
+			name, skip := attrName(attr, key)
+			if skip {
+				continue
+			}
+			switch name {
+			case "size":
+				size = propValue{key, value}
+			case "stroke-width":
+				strokeWidth = propValue{key, value}
+			case "color":
+				color = propValue{key, value}
+			case "absoluteStrokeWidth":
+				absolute = propValue{key, value}
+			case "rotate":
+				rotate = propValue{key, value}
+			case "flip-horizontal":
+				flipHorizontal = propValue{key, value}
+			case "flip-vertical":
+				flipVertical = propValue{key, value}
+			case "animate-duration":
+				duration = propValue{key, value}
+			case "non-scaling-stroke":
+				nonScaling = propValue{key, value}
+			}
+		}
+	}
+	var props iconProps
+	var err error
+	if props.size, err = propStr(size.key, size.value, ""); err != nil {
+		return props, err
+	}
+	if props.strokeWidth, err = propStr(strokeWidth.key, strokeWidth.value, ""); err != nil {
+		return props, err
+	}
+	if props.color, err = propStr(color.key, color.value, ""); err != nil {
+		return props, err
+	}
+	absoluteStrokeWidth, err := propBool(absolute.key, absolute.value)
+	if err != nil {
+		return props, err
+	}
+	if props.rotate, err = propStr(rotate.key, rotate.value, ""); err != nil {
+		return props, err
+	}
+	if props.rotate, err = cssNumberProp(rotate, props.rotate, "angle", "deg", "grad", "rad", "turn"); err != nil {
+		return props, err
+	}
+	if props.flipHorizontal, err = propBool(flipHorizontal.key, flipHorizontal.value); err != nil {
+		return props, err
+	}
+	if props.flipVertical, err = propBool(flipVertical.key, flipVertical.value); err != nil {
+		return props, err
+	}
+	if props.animateDuration, err = propStr(duration.key, duration.value, ""); err != nil {
+		return props, err
+	}
+	if props.animateDuration, err = cssNumberProp(duration, props.animateDuration, "duration", "ms", "s"); err != nil {
+		return props, err
+	}
+	if props.nonScalingStroke, err = propBool(nonScaling.key, nonScaling.value); err != nil {
+		return props, err
+	}
+	// named sizes, the stroke width of the size applies unless one is passed
+	if size, ok := scale[props.size]; ok {
+		props.size = strconv.FormatFloat(size.Px, 'f', -1, 64)
+		if size.StrokeWidth > 0 && strokeWidth.value == nil {
+			props.strokeWidth = strconv.FormatFloat(size.StrokeWidth, 'f', -1, 64)
+		}
+	}
+	if absoluteStrokeWidth {
+		current := props.strokeWidth
+		if current == "" {
+			current = root.get("stroke-width")
+		}
+		strokeWidthFloat, errStrokeWidth := strconv.ParseFloat(current, 64)
+		sizeFloat, errSize := strconv.ParseFloat(props.size, 64)
+		if errStrokeWidth == nil && errSize == nil && sizeFloat > 0 {
+			props.absoluteStrokeWidth = strokeWidthFloat * root.viewBoxSide() / sizeFloat
+		} else {
+			props.nonScalingStroke = true
+		}
+	}
+	return props, nil
+}
+
+// resolveAttrs appends the attributes of the svg element of an icon to
+// defaults and extra, in the order they are rendered in (the class is
+// rendered in between, see resolveClasses):
+//   - defaults holds the root attributes of the icon in the order of its svg
+//     file, which is Lucide's order for the Lucide icons (xmlns, width,
+//     height, viewBox, fill, stroke, stroke-width, stroke-linecap and
+//     stroke-linejoin), followed by the width, height, stroke and stroke-width
+//     set by the size, color and stroke width passed to the icon when the root
+//     element doesn't have them. Attributes passed to the icon with the same
+//     name override their value, keeping their position.
+//   - extra holds the other attributes passed to the icon, sorted by name.
+//
+// The options passed to the icon are resolved by resolveProps. Camel case
+// aliases (e.g. strokeWidth) are normalized to the svg attribute
+// names first, see attrAlias. When several attribute maps set the same
+// attribute, the last one wins, except for styles which are merged along with
+// the options setting them, see resolveStyle. In strict mode, the attributes
+// are checked (see SetStrictAttrs) and the *AttrError of the first invalid
+// key is returned.
+func resolveAttrs[T attributes](defaults []attrValue, extra []attrValue, root rootAttrs, attrs []T, scale SizeScale) ([]attrValue, []attrValue, error) {
+	for _, a := range root {
+		// the class of the root element is part of the classes of the icon
+		if a.name != "class" {
+			defaults = append(defaults, attrValue{name: a.name, str: a.value, computed: true, root: true})
+		}
+	}
+
+	props, err := resolveProps(root, attrs, scale)
+	if err != nil {
+		return nil, nil, err
+	}
+	if props.absoluteStrokeWidth > 0 {
+		props.strokeWidth = strconv.FormatFloat(props.absoluteStrokeWidth, 'f', -1, 64)
+	}
+	if props.size != "" {
+		defaults = setAttr(defaults, attrValue{name: "width", str: props.size, computed: true})
+		defaults = setAttr(defaults, attrValue{name: "height", str: props.size, computed: true})
+	}
+	if props.color != "" {
+		defaults = setAttr(defaults, attrValue{name: "stroke", str: props.color, computed: true})
+	}
+	if props.strokeWidth != "" {
+		defaults = setAttr(defaults, attrValue{name: "stroke-width", str: props.strokeWidth, computed: true})
+	}
+
+	var nameErr *AttrError
+	for _, attr := range attrs {
+		for key, value := range attr {
+			name, skip := attrName(attr, key)
+			if skip {
+				continue
+			}
+			switch name {
+			case "class", "size", "stroke-width", "color", "absoluteStrokeWidth", "style", "rotate", "flip-horizontal", "flip-vertical",
+				"animate", "animate-duration", "animate-always", "non-scaling-stroke":
+				continue
+			}
+			if err := checkAttrName(key, name, value); err != nil {
+				// report the same key whatever the order of the maps
+				if nameErr == nil || key < nameErr.Name {
+					nameErr = err
+				}
+				continue
+			}
+			if i := indexAttr(defaults, name); i >= 0 {
+				defaults[i] = attrValue{name: name, value: value}
+			} else {
+				extra = insertAttr(extra, attrValue{name: name, value: value})
+			}
+		}
+	}
+	if nameErr != nil {
+		return nil, nil, nameErr
+	}
+
+	var declStack [1]styleDecl
+	decls := declStack[:0]
+	if props.animateDuration != "" {
+		decls = append(decls, styleDecl{property: "--lucide-animation-duration", value: animationDuration(props.animateDuration)})
+	}
+	transform := styleTransform(props.rotate, props.flipHorizontal, props.flipVertical)
+	style, hasStyle, err := resolveStyle(root.get("style"), attrs, decls, transform)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hasStyle {
+		styleAttr := attrValue{name: "style", str: style, computed: true}
+		if i := indexAttr(defaults, "style"); i >= 0 {
+			defaults[i] = styleAttr
+		} else {
+			extra = insertAttr(extra, styleAttr)
+		}
+	}
+	return defaults, extra, nil
+}
@@ -0,0 +1,5 @@
+package icons
+
+// Attrs are the attributes of an icon, with the same special keys as the
+// templ package (size, color, stroke-width, absoluteStrokeWidth and class).
+type Attrs map[string]any
@@ -0,0 +1,12 @@
+// Package src holds the runtime helpers that are copied into every generated
+// icon package. The helpers are plain go files (package icons) so that they
+// are compiled and vetted with the rest of the repo.
+package src
+
+import "embed"
+
+// Runtime contains the runtime helpers, one directory per output flavor
+// (common is shared by every flavor).
+//
+//go:embed common/*.go gomponents/*.go
+var Runtime embed.FS
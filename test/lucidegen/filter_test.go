@@ -0,0 +1,70 @@
+package lucidegen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+// kebabNames returns the kebab names of the icons.
+func kebabNames(icons []*lucidegen.LucideIconSvg) []string {
+	names := []string{}
+	for _, icon := range icons {
+		names = append(names, icon.KebabName())
+	}
+	return names
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  []string
+	}{
+		{[]string{"square", "circle"}, []string{"circle", "square"}},
+		{[]string{"Square", " house "}, []string{"house", "square"}},
+		{[]string{"home"}, []string{"house"}},
+		{[]string{"Home", "house"}, []string{"house"}},
+		{[]string{}, []string{}},
+	}
+	for _, tt := range tests {
+		icons, err := lucidegen.Filter(testIcons(), tt.names...)
+		if err != nil {
+			t.Errorf("Filter(%q) error = %v", tt.names, err)
+			continue
+		}
+		if got := kebabNames(icons); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Filter(%q) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+
+	want := "unknown icons: pen, circle-icon"
+	if _, err := lucidegen.Filter(testIcons(), "pen", "circle", "circle-icon"); err == nil || err.Error() != want {
+		t.Errorf("Filter() error = %v, want %q", err, want)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	found, unknown := lucidegen.Resolve(testIcons(), "Home", "pen", "Circle", "PenLine")
+	if got, want := kebabNames(found), []string{"circle", "house"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() found = %v, want %v", got, want)
+	}
+	if want := []string{"pen", "PenLine"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("Resolve() unknown = %v, want %v", unknown, want)
+	}
+}
+
+// Reserved names resolve by the kebab name and the suffixed function name
+func TestResolveReserved(t *testing.T) {
+	icons := []*lucidegen.LucideIconSvg{
+		{LucideIconSvgPath: "icons/node.svg", LucideAliases: []lucidegen.LucideIconAlias{"attrs"}},
+	}
+	for _, name := range []string{"node", "NodeIcon", "attrs", "AttrsIcon"} {
+		if found, unknown := lucidegen.Resolve(icons, name); len(found) != 1 || len(unknown) != 0 {
+			t.Errorf("Resolve(%q) = %v, %v, want [node]", name, kebabNames(found), unknown)
+		}
+	}
+	if _, unknown := lucidegen.Resolve(icons, "Node"); !reflect.DeepEqual(unknown, []string{"Node"}) {
+		t.Errorf("Resolve(Node) unknown = %v, want [Node]", unknown)
+	}
+}
@@ -0,0 +1,132 @@
+package lucidegen_test
+
+import (
+	"os"
+	filepathPkg "path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const lucideSvgRoot = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round">`
+
+// testIcons returns a few icons, house having the alias home.
+func testIcons() []*lucidegen.LucideIconSvg {
+	return []*lucidegen.LucideIconSvg{
+		{LucideIconSvgPath: "icons/circle.svg", LucideSvgContent: lucideSvgRoot + `<circle cx="12" cy="12" r="10" /></svg>`},
+		{LucideIconSvgPath: "icons/house.svg", LucideSvgContent: lucideSvgRoot + `<path d="M3 10l9-7 9 7v11H3z" /></svg>`, LucideAliases: []lucidegen.LucideIconAlias{"home"}},
+		{LucideIconSvgPath: "icons/square.svg", LucideSvgContent: lucideSvgRoot + `<rect x="3" y="3" width="18" height="18" /></svg>`},
+	}
+}
+
+// svgFiles returns the names of the files of the svg directory of a package.
+func svgFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(filepathPkg.Join(dir, "svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestGenerateSvgFiles(t *testing.T) {
+	dir := t.TempDir()
+	opts := lucidegen.Options{Generator: "gomponents", Dir: dir, Module: "example.com/icons"}
+	if err := lucidegen.Generate(testIcons(), opts); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got, want := svgFiles(t, dir), []string{"circle.svg", "home.svg", "house.svg", "square.svg"}; !slices.Equal(got, want) {
+		t.Errorf("svg files = %v, want %v", got, want)
+	}
+	house, err := os.ReadFile(filepathPkg.Join(dir, "svg", "house.svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	home, err := os.ReadFile(filepathPkg.Join(dir, "svg", "home.svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(home) != string(house) {
+		t.Errorf("home.svg =\n%s\nwant the bytes of house.svg\n%s", home, house)
+	}
+
+	// generating again replaces the directory, like -icons selecting icons
+	if err := os.WriteFile(filepathPkg.Join(dir, "svg", "stale.svg"), []byte("<svg/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	icons, err := lucidegen.Filter(testIcons(), "home", "Square")
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if err := lucidegen.Generate(icons, opts); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got, want := svgFiles(t, dir), []string{"home.svg", "house.svg", "square.svg"}; !slices.Equal(got, want) {
+		t.Errorf("svg files = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name  string
+		icons []*lucidegen.LucideIconSvg
+		opts  lucidegen.Options
+		want  string
+	}{
+		{"unknown generator", testIcons(), lucidegen.Options{Generator: "react", Dir: dir, Module: "example.com/icons"}, "unknown generator 'react' (available: " + strings.Join(lucidegen.Generators(), ", ") + ")"},
+		{"no directory", testIcons(), lucidegen.Options{Module: "example.com/icons"}, "no output directory"},
+		{"no module", testIcons(), lucidegen.Options{Dir: dir}, "no module path for " + dir},
+		{"no icons", nil, lucidegen.Options{Dir: dir, Module: "example.com/icons"}, "no icons to generate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := lucidegen.Generate(tt.icons, tt.opts); err == nil || err.Error() != tt.want {
+				t.Errorf("Generate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// namesGenerator writes the kebab names of the icons to names.txt
+type namesGenerator struct{}
+
+func (namesGenerator) Name() string { return "names" }
+
+func (namesGenerator) Generate(in lucidegen.GeneratorInput) error {
+	names := []string{}
+	for _, icon := range in.Icons {
+		names = append(names, icon.KebabName())
+	}
+	return os.WriteFile(filepathPkg.Join(in.Path, "names.txt"), []byte(strings.Join(names, "\n")), 0644)
+}
+
+func TestRegisterGenerator(t *testing.T) {
+	// registered generators are global, so go test -count runs register once
+	if !slices.Contains(lucidegen.Generators(), "names") {
+		if err := lucidegen.RegisterGenerator(namesGenerator{}); err != nil {
+			t.Fatalf("RegisterGenerator() error = %v", err)
+		}
+	}
+	if err := lucidegen.RegisterGenerator(namesGenerator{}); err == nil || err.Error() != "generator 'names' is already registered" {
+		t.Errorf("RegisterGenerator() error = %v, want generator 'names' is already registered", err)
+	}
+
+	dir := t.TempDir()
+	if err := lucidegen.Generate(testIcons(), lucidegen.Options{Generator: "names", Dir: dir, Module: "example.com/icons"}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	names, err := os.ReadFile(filepathPkg.Join(dir, "names.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(names), "circle\nhouse\nsquare"; got != want {
+		t.Errorf("names.txt = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package lucidegen_test
+
+import (
+	"os"
+	filepathPkg "path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+// customIcons are the files of a directory of custom svgs
+var customIcons = map[string]string{
+	"brand-mark.svg":  lucideSvgRoot + `<circle cx="12" cy="12" r="10" /></svg>`,
+	"brand-mark.json": `{"aliases": ["logo", {"name": "brand"}]}`,
+	"chevron.svg":     lucideSvgRoot + `<path d="m9 18 6-6-6-6" /></svg>`,
+	"notes.txt":       "not an icon",
+}
+
+func TestIngestSource(t *testing.T) {
+	// a flat directory of svgs, and a repo with an icons directory
+	flat := t.TempDir()
+	writeFiles(t, flat, customIcons)
+	repo := t.TempDir()
+	writeFiles(t, filepathPkg.Join(repo, "icons"), customIcons)
+
+	for _, dir := range []string{flat, repo} {
+		icons, err := lucidegen.IngestSource(dir)
+		if err != nil {
+			t.Fatalf("IngestSource(%s) error = %v", dir, err)
+		}
+		if got, want := kebabNames(icons), []string{"brand-mark", "chevron"}; !slices.Equal(got, want) {
+			t.Fatalf("IngestSource(%s) = %v, want %v", dir, got, want)
+		}
+		if got, want := icons[0].LucideAliases, []lucidegen.LucideIconAlias{"logo", "brand"}; !slices.Equal(got, want) {
+			t.Errorf("aliases = %v, want %v", got, want)
+		}
+		if got := icons[0].CamelCaseName(); got != "BrandMark" {
+			t.Errorf("CamelCaseName() = %s, want BrandMark", got)
+		}
+	}
+}
+
+func TestCustomIconErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			"name",
+			map[string]string{"Brand_Mark.svg": lucideSvgRoot + `<circle r="10" /></svg>`},
+			"invalid icon name 'Brand_Mark' (%s): names must be lowercase kebab case and start with a letter",
+		},
+		{
+			"element",
+			map[string]string{"brand-mark.svg": lucideSvgRoot + "\n  <g>\n    <circle r=\"10\" />\n  </g>\n</svg>"},
+			"%s:2:3: unsupported element <g>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			// svgs are parsed when read, names are checked when generating
+			icons, err := lucidegen.IngestSource(dir)
+			if err == nil {
+				err = lucidegen.Generate(icons, lucidegen.Options{Dir: filepathPkg.Join(dir, "out"), Module: "example.com/icons"})
+			}
+			for name := range tt.files {
+				tt.want = strings.ReplaceAll(tt.want, "%s", filepathPkg.Join(dir, name))
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+			if _, err := os.Stat(filepathPkg.Join(dir, "out", "icons.go")); err == nil {
+				t.Errorf("Generate() wrote icons.go despite the error")
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package lucidegen_test
+
+import (
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+func parseShapes(t *testing.T, markup string) *lucidegen.Svg {
+	t.Helper()
+	svg, err := lucidegen.ParseSvg("icon.svg", "<svg>"+markup+"</svg>")
+	if err != nil {
+		t.Fatalf("ParseSvg() error = %v", err)
+	}
+	return svg
+}
+
+func TestOptimizeSvg(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+		want  string
+	}{
+		{
+			name:  "path whitespace",
+			shape: `<path d="M 15 21 v -8 a 1 1 0 0 0 -1 -1 h -4 a 1 1 0 0 0 -1 1 v 8" />`,
+			want:  `<path d="M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8" />`,
+		},
+		{
+			name:  "compact path unchanged",
+			shape: `<path d="M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z" />`,
+			want:  `<path d="M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z" />`,
+		},
+		{
+			name:  "path numbers and implicit lineto",
+			shape: `<path d="M0.5,0.5 L 1.5,-0.5 L 2e2 0" />`,
+			want:  `<path d="M.5.5 1.5-.5 200 0" />`,
+		},
+		{
+			name:  "relative implicit lineto kept",
+			shape: `<path d="m1 1 2 2 3 3M4 4l5 5" />`,
+			want:  `<path d="m1 1 2 2 3 3M4 4l5 5" />`,
+		},
+		{
+			name:  "line",
+			shape: `<line x1="3" y1="12" x2="21.0" y2="12" stroke="red" />`,
+			want:  `<path d="M3 12 21 12" stroke="red" />`,
+		},
+		{
+			name:  "polyline",
+			shape: `<polyline points="4 7, 4 4, 20 4, 20 7" />`,
+			want:  `<path d="M4 7 4 4 20 4 20 7" />`,
+		},
+		{
+			name:  "polygon",
+			shape: `<polygon points="12 2 2 7 12 12 22 7 12 2" />`,
+			want:  `<path d="M12 2 2 7 12 12 22 7 12 2Z" />`,
+		},
+		{
+			name:  "square rect",
+			shape: `<rect x="3" y="3" width="18" height="18" />`,
+			want:  `<path d="M3 3h18v18H3z" />`,
+		},
+		{
+			name:  "rounded rect",
+			shape: `<rect x="3" y="3" width="18.0" height="18" rx="2" />`,
+			want:  `<rect x="3" y="3" width="18" height="18" rx="2" />`,
+		},
+		{
+			name:  "empty rect",
+			shape: `<rect width="0" height="5" />`,
+			want:  `<rect width="0" height="5" />`,
+		},
+		{
+			name:  "circle numbers",
+			shape: `<circle cx="12.00" cy="012" r="0.50" />`,
+			want:  `<circle cx="12" cy="12" r=".5" />`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lucidegen.OptimizeSvg(parseShapes(t, tt.shape))
+			if err != nil {
+				t.Fatalf("OptimizeSvg() error = %v", err)
+			}
+			if got.Markup() != tt.want {
+				t.Errorf("OptimizeSvg() = %s, want %s", got.Markup(), tt.want)
+			}
+
+			// the optimized markup parses back to the same, already optimized, svg
+			again, err := lucidegen.OptimizeSvg(parseShapes(t, got.Markup()))
+			if err != nil {
+				t.Fatalf("OptimizeSvg() of the optimized svg error = %v", err)
+			}
+			if again.Markup() != got.Markup() {
+				t.Errorf("OptimizeSvg() of the optimized svg = %s, want %s", again.Markup(), got.Markup())
+			}
+		})
+	}
+}
+
+func TestOptimizeSvgErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+		want  string
+	}{
+		{"path command", `<path d="M0 0 X 1" />`, "<path>: unexpected 'X' at offset 5 of path data"},
+		{"odd points", `<polyline points="1 2 3" />`, "<polyline>: odd number of coordinates in points"},
+		{"geometry unit", `<rect x="1em" y="0" width="2" height="2" />`, `<rect x>: strconv.ParseFloat: parsing "1em": invalid syntax`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lucidegen.OptimizeSvg(parseShapes(t, tt.shape))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("OptimizeSvg() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptimizeIcons(t *testing.T) {
+	icons := []*lucidegen.LucideIconSvg{
+		{LucideIconSvgPath: "icons/square.svg", LucideSvgContent: `<svg><rect x="3" y="3" width="18" height="18" /></svg>`},
+		{LucideIconSvgPath: "icons/broken.svg", LucideSvgContent: `<svg><path d="M0 0 X 1" /></svg>`},
+	}
+	optimized, results, err := lucidegen.OptimizeIcons(icons)
+	if err != nil {
+		t.Fatalf("OptimizeIcons() error = %v", err)
+	}
+	wantMarkup := []string{`<path d="M3 3h18v18H3z" />`, `<path d="M0 0 X 1" />`}
+	for i, icon := range optimized {
+		svg, err := icon.Svg()
+		if err != nil {
+			t.Fatalf("Svg() error = %v", err)
+		}
+		if svg.Markup() != wantMarkup[i] {
+			t.Errorf("OptimizeIcons() %s markup = %s, want %s", icon.KebabName(), svg.Markup(), wantMarkup[i])
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("OptimizeIcons() returned %d results, want 2", len(results))
+	}
+	if want := (lucidegen.OptimizeResult{Name: "square", Before: 43, After: 26}); results[0] != want {
+		t.Errorf("OptimizeIcons() square result = %+v, want %+v", results[0], want)
+	}
+	// icons that cannot be optimized are kept as is
+	if results[1].Err == nil || results[1].Before != results[1].After {
+		t.Errorf("OptimizeIcons() broken result = %+v, want an error and the same size", results[1])
+	}
+}
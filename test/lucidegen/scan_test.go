@@ -0,0 +1,140 @@
+package lucidegen_test
+
+import (
+	"os"
+	"os/exec"
+	filepathPkg "path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const scanImportPath = "example.com/app/icons"
+
+// writeFiles writes the files (path relative to dir, content) into dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepathPkg.Join(dir, name)
+		if err := os.MkdirAll(filepathPkg.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// scanFiles are the sources of an app using the generated icons package
+var scanFiles = map[string]string{
+	"main.go": `package main
+
+import (
+	"os"
+
+	"example.com/app/icons"
+	other "example.com/other/icons"
+)
+
+// House is not the icon
+var House = other.Trash
+
+func main() {
+	icons.House().Render(os.Stdout)
+	uri, _ := icons.DataURI("pen", icons.DataURIOptions{})
+	println(uri, House)
+}
+`,
+	"views/nav.templ": `package views
+
+import lucide "example.com/app/icons"
+
+templ Nav() {
+	<nav>@lucide.Square(templ.Attributes{"size": 16})</nav>
+}
+`,
+	// generated from nav.templ, skipped since the templ file is scanned
+	"views/nav_templ.go": `package views
+
+import lucide "example.com/app/icons"
+
+var _ = lucide.Stale
+`,
+	// generated without its templ file, scanned as go
+	"views/footer_templ.go": `package views
+
+import lucide "example.com/app/icons"
+
+var _ = lucide.Circle
+`,
+	"icons/icons.go":   "package icons\n\nvar _ = Node\n",
+	".cache/cached.go": "package cache\n\nimport \"example.com/app/icons\"\n\nvar _ = icons.Hidden\n",
+}
+
+func TestScanUsage(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, scanFiles)
+	usage, err := lucidegen.ScanUsage(dir, scanImportPath, "icons", filepathPkg.Join(dir, "icons"))
+	if err != nil {
+		t.Fatalf("ScanUsage() error = %v", err)
+	}
+	main, templ, footer := filepathPkg.Join(dir, "main.go"), filepathPkg.Join(dir, "views/nav.templ"), filepathPkg.Join(dir, "views/footer_templ.go")
+	want := lucidegen.Usage{
+		"Circle":         {footer},
+		"DataURI":        {main},
+		"DataURIOptions": {main},
+		"House":          {main},
+		"Square":         {templ},
+	}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("ScanUsage() = %v, want %v", usage, want)
+	}
+}
+
+func TestScanUsageDotImport(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n\nimport . \"example.com/app/icons\"\n\nvar _ = House\n"})
+	_, err := lucidegen.ScanUsage(dir, scanImportPath, "icons")
+	want := filepathPkg.Join(dir, "main.go") + ": dot imports of example.com/app/icons are not supported"
+	if err == nil || err.Error() != want {
+		t.Errorf("ScanUsage() error = %v, want %q", err, want)
+	}
+}
+
+func TestScanCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go run of lucidegen in short mode")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, scanFiles)
+	src := filepathPkg.Join(dir, "src")
+	files := map[string]string{}
+	for _, icon := range testIcons() {
+		files[filepathPkg.Base(icon.LucideIconSvgPath)] = icon.LucideSvgContent
+	}
+	files["house.json"] = `{"aliases": ["home"]}`
+	writeFiles(t, src, files)
+
+	cmd := exec.Command("go", "run", "../../cmd/lucidegen", "-src", src, "-scan", dir, "-out", filepathPkg.Join(dir, "icons"), "-module", scanImportPath, "-generator", "gomponents")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("lucidegen: %v\n%s", err, out)
+	}
+	// icons looked up by name at runtime can't be found by the scan
+	for _, line := range []string{
+		"Warning: icons.DataURI looks up icons by name, icons only used through it are not generated (used in " + filepathPkg.Join(dir, "main.go") + ")",
+		"Found 3 icons used in " + dir + ":",
+	} {
+		if !strings.Contains(string(out), line+"\n") {
+			t.Errorf("lucidegen output =\n%s\nwant the line %s", out, line)
+		}
+	}
+	if strings.Contains(string(out), "DataURIOptions is not an icon") {
+		t.Errorf("lucidegen output =\n%s\nwant no warning for DataURIOptions", out)
+	}
+	if got, want := svgFiles(t, filepathPkg.Join(dir, "icons")), []string{"circle.svg", "home.svg", "house.svg", "square.svg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("svg files = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package lucidegen_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+func TestParseSvg(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *lucidegen.Svg
+	}{
+		{
+			name: "lucide icon",
+			content: `<svg
+  xmlns="http://www.w3.org/2000/svg"
+  width="24"
+  height="24"
+  viewBox="0 0 24 24"
+>
+  <!-- roof -->
+  <path d="M3 10l9-7 9 7" />
+  <circle cx="12" cy="15" r="3"></circle>
+</svg>
+`,
+			want: &lucidegen.Svg{
+				RootAttrs: []lucidegen.SvgAttr{
+					{Name: "xmlns", Value: "http://www.w3.org/2000/svg"},
+					{Name: "width", Value: "24"},
+					{Name: "height", Value: "24"},
+					{Name: "viewBox", Value: "0 0 24 24"},
+				},
+				Elements: []lucidegen.SvgElement{
+					{Tag: "path", Attrs: []lucidegen.SvgAttr{{Name: "d", Value: "M3 10l9-7 9 7"}}},
+					{Tag: "circle", Attrs: []lucidegen.SvgAttr{
+						{Name: "cx", Value: "12"},
+						{Name: "cy", Value: "15"},
+						{Name: "r", Value: "3"},
+					}},
+				},
+			},
+		},
+		{
+			name:    "xml declaration and namespace declarations",
+			content: `<?xml version="1.0"?><svg xmlns:xlink="http://www.w3.org/1999/xlink" class="a &amp; b"><rect x="1"/></svg>`,
+			want: &lucidegen.Svg{
+				RootAttrs: []lucidegen.SvgAttr{{Name: "class", Value: "a & b"}},
+				Elements:  []lucidegen.SvgElement{{Tag: "rect", Attrs: []lucidegen.SvgAttr{{Name: "x", Value: "1"}}}},
+			},
+		},
+		{
+			name:    "no shapes",
+			content: `<svg width="24"/>`,
+			want:    &lucidegen.Svg{RootAttrs: []lucidegen.SvgAttr{{Name: "width", Value: "24"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lucidegen.ParseSvg("icon.svg", tt.content)
+			if err != nil {
+				t.Fatalf("ParseSvg() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSvg() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSvgErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", ``, "icon.svg:1:1: no <svg> element"},
+		{"root element", `<g/>`, "icon.svg:1:1: root element must be <svg>, found <g>"},
+		{"second root", `<svg></svg><svg></svg>`, "icon.svg:1:12: unexpected element <svg> after the root svg element"},
+		{"unclosed root", `<svg>`, "icon.svg:1:6: unclosed <svg> element"},
+		{"invalid xml", `<svg><path`, "icon.svg:1:6: invalid xml: XML syntax error on line 1: unexpected EOF"},
+		{"group", `<svg><g/></svg>`, "icon.svg:1:6: unsupported element <g>"},
+		{"nested element", `<svg><path d="M0 0"><title/></path></svg>`, "icon.svg:1:21: unsupported nested element <title>"},
+		{"text", `<svg>icon</svg>`, `icon.svg:1:6: unsupported text content "icon"`},
+		{"mismatched closing tag", "<svg>\n  <circle r=\"1\"/>\n  </rect>\n</svg>", "icon.svg:3:3: unexpected closing tag </rect>"},
+		{"directive", `<!DOCTYPE svg><svg/>`, "icon.svg:1:1: unsupported directive <!DOCTYPE svg>"},
+		{"duplicate attribute", `<svg><path d="M0 0" d="M1 1"/></svg>`, "icon.svg:1:6: <path>: duplicate attribute d"},
+		{"namespaced attribute", `<svg xmlns:xlink="x"><path xlink:href="#a"/></svg>`, "icon.svg:1:22: <path>: unsupported attribute xlink:href"},
+		{"shape namespace", `<svg><path xmlns="x"/></svg>`, "icon.svg:1:6: <path>: unsupported attribute xmlns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lucidegen.ParseSvg("icon.svg", tt.content)
+			var parseErr *lucidegen.SvgParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseSvg() error = %v, want a *SvgParseError", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("ParseSvg() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasLucideRootAttrs(t *testing.T) {
+	lucideRoot := `xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"`
+	tests := []struct {
+		name string
+		root string
+		want bool
+	}{
+		{"lucide", lucideRoot, true},
+		{"reordered", `width="24" xmlns="http://www.w3.org/2000/svg" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"`, false},
+		{"other value", `xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round"`, false},
+		{"extra attribute", lucideRoot + ` class="icon"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := lucidegen.ParseSvg("icon.svg", "<svg "+tt.root+"/>")
+			if err != nil {
+				t.Fatalf("ParseSvg() error = %v", err)
+			}
+			if got := svg.HasLucideRootAttrs(); got != tt.want {
+				t.Errorf("HasLucideRootAttrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package lucidegen_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+	"github.com/bryanvaz/go-lucide/src"
+)
+
+func TestReservedNames(t *testing.T) {
+	icons := []*lucidegen.LucideIconSvg{
+		{LucideIconSvgPath: "icons/node.svg", LucideAliases: []lucidegen.LucideIconAlias{"attrs"}},
+		{LucideIconSvgPath: "icons/path.svg"},
+	}
+	if err := lucidegen.ValidateIconNames(icons); err != nil {
+		t.Fatalf("ValidateIconNames() error = %v", err)
+	}
+	if got := icons[0].CamelCaseName(); got != "NodeIcon" {
+		t.Errorf("CamelCaseName() = %s, want NodeIcon", got)
+	}
+	if got := icons[0].LucideAliases[0].CamelCaseName(); got != "AttrsIcon" {
+		t.Errorf("alias CamelCaseName() = %s, want AttrsIcon", got)
+	}
+	// path.go is a runtime file, but Path isn't exported by the runtime
+	if got := icons[1].CamelCaseName(); got != "Path" {
+		t.Errorf("CamelCaseName() = %s, want Path", got)
+	}
+
+	// the suffixed function and file can still collide with another icon
+	icons = append(icons, &lucidegen.LucideIconSvg{LucideIconSvgPath: "icons/node-icon.svg"})
+	want := "icon 'node-icon' and 'node' both generate the function NodeIcon"
+	if err := lucidegen.ValidateIconNames(icons); err == nil || err.Error() != want {
+		t.Errorf("ValidateIconNames() error = %v, want %q", err, want)
+	}
+	icons = []*lucidegen.LucideIconSvg{{LucideIconSvgPath: "icons/path.svg"}, {LucideIconSvgPath: "icons/path-icon.svg"}}
+	want = "icon 'path-icon' and 'path' both generate the file path-icon"
+	if err := lucidegen.ValidateIconNames(icons); err == nil || err.Error() != want {
+		t.Errorf("ValidateIconNames() error = %v, want %q", err, want)
+	}
+}
+
+// Every name exported by the runtime helpers copied next to the icons must be
+// reserved, or an icon with that name would not compile.
+func TestRuntimeNamesReserved(t *testing.T) {
+	files, err := fs.Glob(src.Runtime, "*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		content, err := fs.ReadFile(src.Runtime, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := parser.ParseFile(fset, name, content, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ident := range topLevelNames(file) {
+			if ident.IsExported() && !lucidegen.IsReserved(ident.Name) {
+				t.Errorf("%s exports %s, which is not reserved", name, ident.Name)
+			}
+		}
+	}
+}
+
+// topLevelNames returns the names of the functions, types, variables and
+// constants declared by a file.
+func topLevelNames(file *ast.File) []*ast.Ident {
+	names := []*ast.Ident{}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names = append(names, decl.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name)
+				case *ast.ValueSpec:
+					names = append(names, spec.Names...)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestValidateIconNames(t *testing.T) {
+	if err := lucidegen.ValidateIconNames(testIcons()); err != nil {
+		t.Errorf("ValidateIconNames() error = %v", err)
+	}
+	tests := []struct {
+		icon *lucidegen.LucideIconSvg
+		want string
+	}{
+		{
+			&lucidegen.LucideIconSvg{LucideIconSvgPath: "custom/Pen_Line.svg"},
+			"invalid icon name 'Pen_Line' (custom/Pen_Line.svg): names must be lowercase kebab case and start with a letter",
+		},
+		{
+			&lucidegen.LucideIconSvg{LucideIconSvgPath: "custom/3d.svg"},
+			"invalid icon name '3d' (custom/3d.svg): names must be lowercase kebab case and start with a letter",
+		},
+		{
+			&lucidegen.LucideIconSvg{LucideIconSvgPath: "custom/pen.svg", LucideAliases: []lucidegen.LucideIconAlias{"pen--line"}},
+			"invalid alias 'pen--line' for icon 'pen': names must be lowercase kebab case and start with a letter",
+		},
+		{
+			&lucidegen.LucideIconSvg{LucideIconSvgPath: "custom/circle.svg"},
+			"icon 'circle' and 'circle' both generate the function Circle",
+		},
+	}
+	for _, tt := range tests {
+		err := lucidegen.ValidateIconNames(append(testIcons(), tt.icon))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("ValidateIconNames(%s) error = %v, want %q", tt.icon.LucideIconSvgPath, err, tt.want)
+		}
+	}
+}
+
+func TestFindCollisions(t *testing.T) {
+	lab := []*lucidegen.LucideIconSvg{
+		{LucideIconSvgPath: "icons/pen.svg"},
+		{LucideIconSvgPath: "icons/home.svg", LucideAliases: []lucidegen.LucideIconAlias{"circle"}},
+		{LucideIconSvgPath: "icons/square.svg"},
+	}
+	if got, want := lucidegen.FindCollisions(testIcons(), lab), []string{"circle", "home", "square"}; !slices.Equal(got, want) {
+		t.Errorf("FindCollisions() = %v, want %v", got, want)
+	}
+	if got := lucidegen.FindCollisions(testIcons(), lab[:1]); len(got) != 0 {
+		t.Errorf("FindCollisions() = %v, want none", got)
+	}
+}
@@ -0,0 +1,164 @@
+// Package render_test checks the markup of the icons of the packages written
+// by each generator. It generates a package from the svgs of testdata/icons
+// into a temporary module, along with the test files of testdata for the
+// generator, and runs them with go test.
+package render_test
+
+import (
+	"os"
+	"os/exec"
+	filepathPkg "path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bryanvaz/go-lucide/lucidegen"
+)
+
+const (
+	RENDER_MODULE  = "lucidetest"
+	RENDER_PACKAGE = RENDER_MODULE + "/lucide"
+)
+
+// Test files of testdata run against the package of every generator
+var sharedTests = []string{"cases_test.go", "nodes_test.go", "raster_test.go"}
+
+// Test files of testdata run against the package of each generator, along
+// with sharedTests
+var generatorTests = []struct {
+	Generator string
+	Files     []string
+}{
+	{"templ", []string{"templ_test.go"}},
+	{"templ-go", []string{"templ_test.go"}},
+	{"gomponents", []string{"gomponents_test.go"}},
+}
+
+func TestGenerators(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of the generated packages in short mode")
+	}
+	for _, gt := range generatorTests {
+		t.Run(gt.Generator, func(t *testing.T) {
+			t.Parallel()
+			files := map[string]string{}
+			for _, name := range append(gt.Files, sharedTests...) {
+				content, err := os.ReadFile(filepathPkg.Join("testdata", name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				files[name] = string(content)
+			}
+			dir := generateModule(t, gt.Generator, files)
+			goCmd(t, dir, "test", "-count", "1", ".")
+		})
+	}
+}
+
+// TestLinkedIcons checks that a binary only links the icons it calls, and not
+// every icon of the root package it imports.
+func TestLinkedIcons(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go build of the generated packages in short mode")
+	}
+	for _, gt := range generatorTests {
+		t.Run(gt.Generator, func(t *testing.T) {
+			t.Parallel()
+			render := "Render(io.Discard)"
+			imports := []string{`"io"`, `icons "` + RENDER_PACKAGE + `"`}
+			if gt.Generator != "gomponents" {
+				render = "Render(context.Background(), io.Discard)"
+				imports = append(imports, `"context"`)
+			}
+			program := strings.Join([]string{
+				"package main",
+				"",
+				"import (\n\t" + strings.Join(imports, "\n\t") + "\n)",
+				"",
+				"func main() {",
+				"\ticons.House()." + render,
+				"\ticons.Square()." + render,
+				"}",
+				"",
+			}, "\n")
+			dir := generateModule(t, gt.Generator, map[string]string{"main.go": program})
+			bin := filepathPkg.Join(dir, "icons.bin")
+			goCmd(t, dir, "build", "-o", bin, ".")
+
+			symbols := map[string]bool{}
+			for _, line := range strings.Split(goCmd(t, dir, "tool", "nm", bin), "\n") {
+				if fields := strings.Fields(line); len(fields) == 3 {
+					symbols[fields[2]] = true
+				}
+			}
+			icons, err := lucidegen.IngestSource("testdata/icons")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// small icon functions are inlined, so the closures they return
+			// and the svg element they render are looked up as well
+			linked := []string{}
+			for _, icon := range icons {
+				name := icon.CamelCaseName()
+				symbol := RENDER_PACKAGE + "/icons." + name
+				svgSymbol := RENDER_PACKAGE + "/icons." + strings.ToLower(name[:1]) + name[1:] + "Svg"
+				if symbols[symbol] || symbols[symbol+".func1"] || symbols[svgSymbol] {
+					linked = append(linked, name)
+				}
+			}
+			if strings.Join(linked, ",") != "House,Square" {
+				t.Errorf("linked icons = %v, want [House Square]", linked)
+			}
+		})
+	}
+}
+
+// generateModule generates the package of the generator from testdata/icons
+// into a temporary module with the given files, and returns its directory.
+func generateModule(t *testing.T, generator string, files map[string]string) string {
+	t.Helper()
+	icons, err := lucidegen.IngestSource("testdata/icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = lucidegen.Generate(icons, lucidegen.Options{
+		Generator: generator,
+		Dir:       filepathPkg.Join(dir, "lucide"),
+		Module:    RENDER_PACKAGE,
+		GoMod:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["go.mod"] = strings.Join([]string{
+		"module " + RENDER_MODULE,
+		"",
+		"go 1.23",
+		"",
+		"require " + RENDER_PACKAGE + " v0.0.0",
+		"",
+		"replace " + RENDER_PACKAGE + " => ./lucide",
+		"",
+	}, "\n")
+	for name, content := range files {
+		if err := os.WriteFile(filepathPkg.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	goCmd(t, filepathPkg.Join(dir, "lucide"), "mod", "tidy")
+	goCmd(t, dir, "mod", "tidy")
+	return dir
+}
+
+// goCmd runs the go command in dir and returns its output, failing the test
+// with the output if it fails.
+func goCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
@@ -0,0 +1,515 @@
+package render_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	icons "lucidetest/lucide"
+)
+
+// Opening tag of the house icon rendered with the default attributes
+const houseOpen = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`
+
+// Stringer value of an attribute
+type hexColor string
+
+func (c hexColor) String() string { return "#" + string(c) }
+
+// Named string type of a style, like templ.SafeCSS
+type cssString string
+
+// renderCase is the opening tag of an icon (the house icon if empty)
+// rendered with attrs.
+type renderCase struct {
+	name  string
+	icon  string
+	attrs []map[string]any
+	want  string
+}
+
+func TestAttributeOrder(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name: "default",
+			want: houseOpen,
+		},
+		{
+			name:  "empty attributes",
+			attrs: []map[string]any{{}, nil},
+			want:  houseOpen,
+		},
+		{
+			name:  "lucide attributes keep their order",
+			attrs: []map[string]any{{"stroke-linejoin": "miter", "fill": "red", "viewBox": "0 0 12 12", "xmlns": "http://www.w3.org/2000/svg"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 12 12" fill="red" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="miter" class="lucide lucide-house">`,
+		},
+		{
+			name:  "class then sorted extras",
+			attrs: []map[string]any{{"id": "x", "data-id": "1", "aria-hidden": "true", "class": "icon"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon lucide lucide-house" aria-hidden="true" data-id="1" id="x">`,
+		},
+		{
+			name:  "options",
+			attrs: []map[string]any{{"size": "32", "color": "red", "stroke-width": "1.5"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 24 24" fill="none" stroke="red" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "absolute stroke width",
+			attrs: []map[string]any{{"size": "48", "stroke-width": "1", "absoluteStrokeWidth": true}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="0.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "attributes override options",
+			attrs: []map[string]any{{"size": "32", "width": "10", "color": "red", "stroke": "blue"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="32" viewBox="0 0 24 24" fill="none" stroke="blue" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "later attributes win",
+			attrs: []map[string]any{{"size": "16", "id": "a", "data-x": "1"}, {"size": "20", "id": "b"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="20" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" data-x="1" id="b">`,
+		},
+		{
+			name:  "escaped values",
+			attrs: []map[string]any{{"data-x": `a"<b`}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" data-x="a&#34;&lt;b">`,
+		},
+	})
+}
+
+func TestAttributeValues(t *testing.T) {
+	on, text := true, "ptr"
+	runCases(t, []renderCase{
+		{
+			name:  "numbers and stringers",
+			attrs: []map[string]any{{"size": uint8(20), "color": hexColor("f00"), "stroke-width": 1.5, "data-n": 3.25}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="20" viewBox="0 0 24 24" fill="none" stroke="#f00" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" data-n="3.25">`,
+		},
+		{
+			name:  "camelCase aliases",
+			attrs: []map[string]any{{"strokeWidth": 3, "strokeLinecap": "square", "className": "a", "tabIndex": 0}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="square" stroke-linejoin="round" class="a lucide lucide-house" tabindex="0">`,
+		},
+		{
+			name:  "kebab case wins over camelCase",
+			attrs: []map[string]any{{"strokeWidth": 3, "stroke-width": "1"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "booleans and pointers",
+			attrs: []map[string]any{{"hidden": true, "off": false, "aria-label": &text, "disabled": &on, "focusable": func() bool { return false }}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" aria-label="ptr" disabled hidden>`,
+		},
+		{
+			name:  "unsupported values are ignored",
+			attrs: []map[string]any{{"size": []int{1}, "absoluteStrokeWidth": "yes", "data-x": nil}},
+			want:  houseOpen,
+		},
+	})
+}
+
+func TestClasses(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name:  "sorted with the icon classes",
+			attrs: []map[string]any{{"class": "nav-icon  b a"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="a b lucide lucide-house nav-icon">`,
+		},
+		{
+			name:  "merged across attributes without duplicates",
+			attrs: []map[string]any{{"class": "z lucide"}, {"class": "y z"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house y z">`,
+		},
+		{
+			name:  "string slice",
+			attrs: []map[string]any{{"class": []string{"b", "a"}}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="a b lucide lucide-house">`,
+		},
+		{
+			name:  "conditional classes",
+			attrs: []map[string]any{{"class": map[string]bool{"on": true, "off": false, "lucide-house": false}}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide on">`,
+		},
+		{
+			name:  "empty class",
+			attrs: []map[string]any{{"class": ""}},
+			want:  houseOpen,
+		},
+	})
+}
+
+func TestStyles(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name:  "style",
+			attrs: []map[string]any{{"style": "color: red"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" style="color: red">`,
+		},
+		{
+			name:  "merged across attributes, the last value wins",
+			attrs: []map[string]any{{"style": "color: red; margin: 0;"}, {"style": cssString("color: blue")}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" style="color: blue; margin: 0">`,
+		},
+		{
+			name:  "rotate",
+			attrs: []map[string]any{{"rotate": 90}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" style="transform: rotate(90deg)">`,
+		},
+		{
+			name:  "rotate with a unit and flips after the style transform",
+			attrs: []map[string]any{{"style": "transform: translateY(1px)", "rotate": "0.25turn", "flip-horizontal": true, "flipVertical": true}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" style="transform: translateY(1px) rotate(0.25turn) scaleX(-1) scaleY(-1)">`,
+		},
+		{
+			name:  "invalid rotate is ignored",
+			attrs: []map[string]any{{"rotate": "45deg); background: url(x)"}},
+			want:  houseOpen,
+		},
+	})
+}
+
+func TestRootAttributeOrder(t *testing.T) {
+	// an icon with the Lucide root attributes in another order keeps it, with
+	// and without attributes
+	reorderedOpen := `<svg width="24" height="24" viewBox="0 0 24 24" xmlns="http://www.w3.org/2000/svg" stroke="currentColor" fill="none" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" class="lucide lucide-reordered">`
+	runCases(t, []renderCase{
+		{
+			name: "default",
+			icon: "reordered",
+			want: reorderedOpen,
+		},
+		{
+			name:  "attributes",
+			icon:  "reordered",
+			attrs: []map[string]any{{"data-x": nil}},
+			want:  reorderedOpen,
+		},
+		{
+			name:  "options",
+			icon:  "reordered",
+			attrs: []map[string]any{{"size": 32, "color": "red"}},
+			want:  `<svg width="32" height="32" viewBox="0 0 24 24" xmlns="http://www.w3.org/2000/svg" stroke="red" fill="none" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" class="lucide lucide-reordered">`,
+		},
+	})
+}
+
+func TestAlias(t *testing.T) {
+	// an alias renders the markup of its icon, with the classes of the icon
+	runCases(t, []renderCase{
+		{
+			name: "default",
+			icon: "home",
+			want: houseOpen,
+		},
+		{
+			name:  "options",
+			icon:  "home",
+			attrs: []map[string]any{{"size": 16, "class": "nav-icon"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house nav-icon">`,
+		},
+	})
+	home, err := renderIcon("home")
+	if err != nil {
+		t.Fatalf("renderIcon(home) error = %v", err)
+	}
+	house, err := renderIcon("house")
+	if err != nil {
+		t.Fatalf("renderIcon(house) error = %v", err)
+	}
+	if home != house {
+		t.Errorf("renderIcon(home) =\n%s\nwant the markup of house\n%s", home, house)
+	}
+}
+
+func TestAnimations(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name:  "animate",
+			attrs: []map[string]any{{"animate": "spin"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-spin lucide-house">`,
+		},
+		{
+			name:  "animate always with other classes",
+			attrs: []map[string]any{{"animate": "pulse", "animate-always": true, "class": "nav-icon"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-always lucide-animate-pulse lucide-house nav-icon">`,
+		},
+		{
+			name:  "animate always without an animation",
+			attrs: []map[string]any{{"animate-always": true}},
+			want:  houseOpen,
+		},
+		{
+			name:  "unknown animation is ignored",
+			attrs: []map[string]any{{"animate": "wiggle"}},
+			want:  houseOpen,
+		},
+		{
+			name:  "duration in milliseconds",
+			attrs: []map[string]any{{"animate": "bounce", "animate-duration": 500}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-bounce lucide-house" style="--lucide-animation-duration: 500ms">`,
+		},
+		{
+			name:  "duration with a unit",
+			attrs: []map[string]any{{"animate": "spin", "animate-duration": "2s", "rotate": 45}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-spin lucide-house" style="--lucide-animation-duration: 2s; transform: rotate(45deg)">`,
+		},
+		{
+			name:  "invalid durations are ignored",
+			attrs: []map[string]any{{"animate": "spin", "animate-duration": "1s; color: red"}, {"animate-duration": "fast"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-spin lucide-house">`,
+		},
+		{
+			name:  "unsupported duration unit is ignored",
+			attrs: []map[string]any{{"animate": "spin", "animate-duration": "2deg"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-animate lucide-animate-spin lucide-house">`,
+		},
+	})
+}
+
+func TestNamedSizes(t *testing.T) {
+	cases := []renderCase{}
+	for _, size := range []struct {
+		name string
+		px   string
+	}{{"xs", "12"}, {"sm", "16"}, {"md", "20"}, {"lg", "24"}, {"xl", "32"}, {"2xl", "40"}} {
+		cases = append(cases, renderCase{
+			name:  size.name,
+			attrs: []map[string]any{{"size": size.name}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="` + size.px + `" height="` + size.px + `" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		})
+	}
+	cases = append(cases, renderCase{
+		name:  "absolute stroke width",
+		attrs: []map[string]any{{"size": "xl", "absoluteStrokeWidth": true}},
+		want:  `<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+	})
+	runCases(t, cases)
+}
+
+func TestSetSizeScale(t *testing.T) {
+	icons.SetSizeScale(icons.SizeScale{"sm": {Px: 16, StrokeWidth: 1.5}, "hero": {Px: 64}})
+	defer icons.SetSizeScale(icons.DefaultSizeScale())
+	runCases(t, []renderCase{
+		{
+			name:  "stroke width of the size",
+			attrs: []map[string]any{{"size": "sm"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "stroke width passed to the icon wins",
+			attrs: []map[string]any{{"size": "sm", "strokeWidth": 3}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "size without a stroke width",
+			attrs: []map[string]any{{"size": "hero"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="64" height="64" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "absolute stroke width of the size",
+			attrs: []map[string]any{{"size": "hero", "stroke-width": 4, "absoluteStrokeWidth": true}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="64" height="64" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "names of the default scale are replaced",
+			attrs: []map[string]any{{"size": "xl"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="xl" height="xl" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+	})
+}
+
+func TestCSSVars(t *testing.T) {
+	icons.SetCSSVars(true)
+	defer icons.SetCSSVars(false)
+	runCases(t, []renderCase{
+		{
+			name: "default",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house lucide-var-size lucide-var-stroke lucide-var-stroke-width">`,
+		},
+		{
+			name:  "options passed to the icon",
+			attrs: []map[string]any{{"size": 32, "class": "nav-icon"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house lucide-var-stroke lucide-var-stroke-width nav-icon">`,
+		},
+		{
+			name:  "attributes passed to the icon",
+			attrs: []map[string]any{{"color": "red", "width": 20, "stroke-width": 1}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="24" viewBox="0 0 24 24" fill="none" stroke="red" stroke-width="1" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name: "icon with other defaults",
+			icon: "frame",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="0 0 48 48" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-frame lucide-var-stroke">`,
+		},
+	})
+}
+
+func TestAbsoluteStrokeWidth(t *testing.T) {
+	// the stroke width is scaled by the largest side of the viewBox
+	runCases(t, []renderCase{
+		{
+			name:  "viewBox larger than the size",
+			icon:  "frame",
+			attrs: []map[string]any{{"size": 24, "absoluteStrokeWidth": true}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 48 48" fill="none" stroke="currentColor" stroke-width="6" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-frame">`,
+		},
+		{
+			name:  "viewBox smaller than the size",
+			icon:  "frame",
+			attrs: []map[string]any{{"size": "96", "stroke-width": 2, "absoluteStrokeWidth": true}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="96" height="96" viewBox="0 0 48 48" fill="none" stroke="currentColor" stroke-width="1" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-frame">`,
+		},
+	})
+}
+
+func TestNonScalingStroke(t *testing.T) {
+	tests := []struct {
+		name  string
+		icon  string
+		attrs map[string]any
+		open  string
+	}{
+		{
+			name:  "non-scaling-stroke option",
+			icon:  "house",
+			attrs: map[string]any{"non-scaling-stroke": true, "size": 48},
+			open:  `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "absolute stroke width of a css size",
+			icon:  "house",
+			attrs: map[string]any{"absoluteStrokeWidth": true, "size": "2rem"},
+			open:  `<svg xmlns="http://www.w3.org/2000/svg" width="2rem" height="2rem" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`,
+		},
+		{
+			name:  "absolute stroke width of a size set by a class",
+			icon:  "frame",
+			attrs: map[string]any{"absoluteStrokeWidth": true, "class": "size-8"},
+			open:  `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="0 0 48 48" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-frame size-8">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderIcon(tt.icon, tt.attrs)
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			if open := openTag(got); open != tt.open {
+				t.Errorf("render() =\n%s\nwant\n%s", open, tt.open)
+			}
+			shapes := shapeTags(got)
+			if len(shapes) != 2 {
+				t.Fatalf("render() = %s, want 2 shapes", got)
+			}
+			for _, shape := range shapes {
+				if !strings.Contains(shape, ` vector-effect="non-scaling-stroke"`) {
+					t.Errorf("render() shape %s has no non scaling stroke", shape)
+				}
+			}
+		})
+	}
+
+	// shapes keep their stroke without the options
+	got, err := renderIcon("frame", map[string]any{"size": 24, "absoluteStrokeWidth": true})
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if strings.Contains(got, "vector-effect") {
+		t.Errorf("render() = %s, want no non scaling stroke", got)
+	}
+}
+
+func TestStrictAttrs(t *testing.T) {
+	icons.SetStrictAttrs(true)
+	defer icons.SetStrictAttrs(false)
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  string
+	}{
+		{"unsupported value", map[string]any{"size": []int{1}}, `icons: attribute "size": unsupported value of type []int`},
+		{"unsupported extra value", map[string]any{"data-x": struct{}{}}, `icons: attribute "data-x": unsupported value of type struct {}`},
+		{"misspelled camelCase name", map[string]any{"strokeWidht": 2}, `icons: attribute "strokeWidht": unknown attribute`},
+		{"invalid rotate", map[string]any{"rotate": "9;x"}, `icons: attribute "rotate": invalid angle "9;x"`},
+		{"invalid animation duration", map[string]any{"animate-duration": "1s; color: red"}, `icons: attribute "animate-duration": invalid duration "1s; color: red"`},
+		{"unsupported animation duration unit", map[string]any{"animate-duration": "2deg"}, `icons: attribute "animate-duration": invalid duration "2deg"`},
+		{"unknown animation", map[string]any{"animate": "wiggle"}, `icons: attribute "animate": unknown animation "wiggle"`},
+		{"invalid boolean", map[string]any{"absoluteStrokeWidth": "yes"}, `icons: attribute "absoluteStrokeWidth": invalid boolean yes`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := render(tt.attrs)
+			var attrErr *icons.AttrError
+			if !errors.As(err, &attrErr) {
+				t.Fatalf("render() error = %v, want an *AttrError", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("render() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// valid attributes still render
+	got, err := render(map[string]any{"size": 32, "strokeWidth": 1.5})
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`
+	if open := openTag(got); open != want {
+		t.Errorf("render() =\n%s\nwant\n%s", open, want)
+	}
+}
+
+func runCases(t *testing.T, cases []renderCase) {
+	t.Helper()
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			icon := tt.icon
+			if icon == "" {
+				icon = "house"
+			}
+			got, err := renderIcon(icon, tt.attrs...)
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			if open := openTag(got); open != tt.want {
+				t.Errorf("render() =\n%s\nwant\n%s", open, tt.want)
+			}
+		})
+	}
+}
+
+// openTag returns the markup of an icon up to the end of its opening svg tag,
+// including the styles rendered before it.
+func openTag(markup string) string {
+	start := strings.Index(markup, "<svg")
+	return markup[:start+strings.IndexByte(markup[start:], '>')+1]
+}
+
+func TestReservedName(t *testing.T) {
+	// the function of the node icon is NodeIcon, since Node is a runtime type
+	runCases(t, []renderCase{
+		{
+			name: "default",
+			icon: "node",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-node">`,
+		},
+	})
+}
+
+// shapeTags returns the opening tags of the shapes of an icon.
+func shapeTags(markup string) []string {
+	tags := []string{}
+	rest := markup[len(openTag(markup)):]
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			return tags
+		}
+		end := start + strings.IndexByte(rest[start:], '>') + 1
+		if tag := rest[start:end]; !strings.HasPrefix(tag, "</") {
+			tags = append(tags, tag)
+		}
+		rest = rest[end:]
+	}
+}
@@ -0,0 +1,68 @@
+package render_test
+
+import (
+	"strings"
+	"testing"
+
+	icons "lucidetest/lucide"
+	g "maragu.dev/gomponents"
+)
+
+// Icons of testdata/icons by name
+var iconFuncs = map[string]func(...icons.Attrs) g.Node{
+	"circle":    icons.Circle,
+	"frame":     icons.Frame,
+	"home":      icons.Home,
+	"house":     icons.House,
+	"minus":     icons.Minus,
+	"node":      icons.NodeIcon,
+	"reordered": icons.Reordered,
+	"square":    icons.Square,
+}
+
+// render renders the house icon with attrs.
+func render(attrs ...map[string]any) (string, error) {
+	return renderIcon("house", attrs...)
+}
+
+func renderIcon(name string, attrs ...map[string]any) (string, error) {
+	iconAttrs := []icons.Attrs{}
+	for _, a := range attrs {
+		iconAttrs = append(iconAttrs, a)
+	}
+	var b strings.Builder
+	err := iconFuncs[name](iconAttrs...).Render(&b)
+	return b.String(), err
+}
+
+func TestHouse(t *testing.T) {
+	got, err := render()
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	want := houseOpen +
+		`<path d="M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8" />` +
+		`<path d="M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z" /></svg>`
+	if got != want {
+		t.Errorf("render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	tests := []struct {
+		nonce string
+		want  string
+	}{
+		{"", `<style>` + icons.AnimationCSS + icons.ThemeCSS + `</style>`},
+		{`a"b`, `<style nonce="a&#34;b">` + icons.AnimationCSS + icons.ThemeCSS + `</style>`},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := icons.Styles(tt.nonce).Render(&b); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Styles(%q) =\n%s\nwant\n%s", tt.nonce, b.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package render_test
+
+import (
+	"bytes"
+	"io/fs"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	icons "lucidetest/lucide"
+)
+
+func TestIconNodes(t *testing.T) {
+	tests := []struct {
+		name string
+		want icons.IconNode
+	}{
+		{"square", icons.IconNode{
+			{Tag: "rect", Attrs: nodeAttrs("width", "18", "height", "18", "x", "3", "y", "3", "rx", "2")},
+		}},
+		{"house", icons.IconNode{
+			{Tag: "path", Attrs: nodeAttrs("d", "M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8")},
+			{Tag: "path", Attrs: nodeAttrs("d", "M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z")},
+		}},
+		{"frame", icons.IconNode{
+			{Tag: "rect", Attrs: nodeAttrs("x", "6", "y", "6", "width", "36", "height", "36", "rx", "4")},
+			{Tag: "path", Attrs: nodeAttrs("d", "M6 18h36")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := icons.IconNodes(tt.name)
+			if !ok {
+				t.Fatalf("IconNodes(%q) not found", tt.name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IconNodes(%q) = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// aliases return the shapes of their icon
+	house, _ := icons.IconNodes("house")
+	home, ok := icons.IconNodes("home")
+	if !ok || !reflect.DeepEqual(home, house) {
+		t.Errorf("IconNodes(\"home\") = %+v, %v, want the shapes of house", home, ok)
+	}
+
+	// only kebab case names are looked up
+	for _, name := range []string{"unknown", "House", ""} {
+		if node, ok := icons.IconNodes(name); ok || node != nil {
+			t.Errorf("IconNodes(%q) = %+v, %v, want nil, false", name, node, ok)
+		}
+	}
+}
+
+func TestDataURI(t *testing.T) {
+	tests := []struct {
+		name string
+		opts icons.DataURIOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: `<svg xmlns='http://www.w3.org/2000/svg' width='24' height='24' viewBox='0 0 24 24' fill='none' stroke='currentColor' stroke-width='2' stroke-linecap='round' stroke-linejoin='round'><path d='M5 12h14'/></svg>`,
+		},
+		{
+			name: "color and size",
+			opts: icons.DataURIOptions{Color: "#2563eb", Size: 48},
+			want: `<svg xmlns='http://www.w3.org/2000/svg' width='48' height='48' viewBox='0 0 24 24' fill='none' stroke='#2563eb' stroke-width='2' stroke-linecap='round' stroke-linejoin='round'><path d='M5 12h14'/></svg>`,
+		},
+		{
+			name: "escaped color",
+			opts: icons.DataURIOptions{Color: `red' onload='x`},
+			want: `<svg xmlns='http://www.w3.org/2000/svg' width='24' height='24' viewBox='0 0 24 24' fill='none' stroke='red&#39; onload=&#39;x' stroke-width='2' stroke-linecap='round' stroke-linejoin='round'><path d='M5 12h14'/></svg>`,
+		},
+		{
+			name: "absolute stroke width",
+			opts: icons.DataURIOptions{Size: 48, StrokeWidth: 1, AbsoluteStrokeWidth: true},
+			want: `<svg xmlns='http://www.w3.org/2000/svg' width='48' height='48' viewBox='0 0 24 24' fill='none' stroke='currentColor' stroke-width='0.5' stroke-linecap='round' stroke-linejoin='round'><path d='M5 12h14'/></svg>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, ok := icons.DataURI("minus", tt.opts)
+			if !ok {
+				t.Fatal("DataURI() not found")
+			}
+			svg, found := strings.CutPrefix(uri, "data:image/svg+xml,")
+			if !found {
+				t.Fatalf("DataURI() = %s, want a data:image/svg+xml uri", uri)
+			}
+			// the uri can be put in a double quoted css url() or html attribute
+			if strings.ContainsAny(svg, "\"#<> ") {
+				t.Errorf("DataURI() = %s, has unencoded characters", uri)
+			}
+			decoded, err := url.PathUnescape(svg)
+			if err != nil {
+				t.Fatalf("DataURI() = %s: %v", uri, err)
+			}
+			if decoded != tt.want {
+				t.Errorf("DataURI() svg =\n%s\nwant\n%s", decoded, tt.want)
+			}
+		})
+	}
+
+	if uri, ok := icons.DataURI("unknown", icons.DataURIOptions{}); ok || uri != "" {
+		t.Errorf("DataURI(\"unknown\") = %q, %v, want \"\", false", uri, ok)
+	}
+}
+
+func TestStylesheetFile(t *testing.T) {
+	content, err := os.ReadFile("lucide/icons.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := string(content)
+	if !strings.Contains(css, icons.ThemeCSS) {
+		t.Errorf("icons.css doesn't have the rules of ThemeCSS")
+	}
+	// the mask of an icon and its aliases is the data URI of the icon
+	for _, name := range []string{"house", "minus"} {
+		uri, _ := icons.DataURI(name, icons.DataURIOptions{})
+		selectors := ".lucide-icon-" + name
+		if name == "house" {
+			selectors += ",\n.lucide-icon-home"
+		}
+		want := selectors + " {\n  -webkit-mask-image: url(\"" + uri + "\");\n  mask-image: url(\"" + uri + "\");\n}"
+		if !strings.Contains(css, want) {
+			t.Errorf("icons.css doesn't have the rule\n%s", want)
+		}
+	}
+}
+
+func TestFS(t *testing.T) {
+	house, err := fs.ReadFile(icons.FS, "house.svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(house, []byte("<svg")) || !bytes.Contains(house, []byte(`<path d="M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8" />`)) {
+		t.Errorf("house.svg =\n%s\nwant the svg of the house icon", house)
+	}
+	// aliases are copies of the svg of their icon
+	home, err := fs.ReadFile(icons.FS, "home.svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(home, house) {
+		t.Errorf("home.svg =\n%s\nwant the bytes of house.svg", home)
+	}
+
+	entries, err := fs.ReadDir(icons.FS, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	want := "circle.svg frame.svg home.svg house.svg minus.svg node.svg reordered.svg square.svg"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("FS files = %s, want %s", got, want)
+	}
+	if _, err := icons.FS.Open("../icons.go"); err == nil {
+		t.Error("FS.Open(\"../icons.go\") error = nil")
+	}
+}
+
+// nodeAttrs returns the attributes of the name and value pairs.
+func nodeAttrs(pairs ...string) []icons.Attr {
+	list := []icons.Attr{}
+	for i := 0; i < len(pairs); i += 2 {
+		list = append(list, icons.Attr{Name: pairs[i], Value: pairs[i+1]})
+	}
+	return list
+}
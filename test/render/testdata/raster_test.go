@@ -0,0 +1,99 @@
+package render_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	icons "lucidetest/lucide"
+)
+
+// thickness returns the number of mostly opaque pixels of the column x of img.
+func thickness(img *image.RGBA, x int) int {
+	n := 0
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		if img.RGBAAt(x, y).A > 127 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRasterize(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	img := icons.Rasterize("minus", 24, red, 0)
+	if img == nil {
+		t.Fatal("Rasterize() = nil")
+	}
+	// the line from (5,12) to (19,12) is 2px wide, with round caps
+	if got := img.RGBAAt(12, 11); got != red {
+		t.Errorf("Rasterize() pixel (12,11) = %v, want %v", got, red)
+	}
+	if got := thickness(img, 12); got != 2 {
+		t.Errorf("Rasterize() line thickness = %d, want 2", got)
+	}
+	if got := img.RGBAAt(4, 11).A; got == 0 {
+		t.Errorf("Rasterize() pixel (4,11) of the round cap is transparent")
+	}
+	for _, p := range []image.Point{{3, 11}, {12, 9}, {12, 14}, {20, 9}} {
+		if got := img.RGBAAt(p.X, p.Y).A; got != 0 {
+			t.Errorf("Rasterize() pixel %v alpha = %d, want 0", p, got)
+		}
+	}
+
+	// aliases are rasterized, unknown icons are not
+	if icons.Rasterize("home", 24, red, 0) == nil {
+		t.Error("Rasterize() of an alias = nil")
+	}
+	if img := icons.Rasterize("unknown", 24, red, 0); img != nil {
+		t.Error("Rasterize() of an unknown icon != nil")
+	}
+}
+
+func TestDraw(t *testing.T) {
+	// the stroke width scales with the icon, unless it is absolute
+	tests := []struct {
+		name string
+		opts icons.RasterOptions
+		want int
+	}{
+		{"relative", icons.RasterOptions{}, 4},
+		{"relative stroke width", icons.RasterOptions{StrokeWidth: 1}, 2},
+		{"absolute stroke width", icons.RasterOptions{StrokeWidth: 2, AbsoluteStrokeWidth: true}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 48, 48))
+			if err := icons.Draw(img, img.Bounds(), "minus", tt.opts); err != nil {
+				t.Fatalf("Draw() error = %v", err)
+			}
+			if got := thickness(img, 24); got != tt.want {
+				t.Errorf("Draw() line thickness = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("rectangle", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+		r := image.Rect(32, 16, 56, 40)
+		if err := icons.Draw(img, r, "minus", icons.RasterOptions{}); err != nil {
+			t.Fatalf("Draw() error = %v", err)
+		}
+		if got := img.RGBAAt(32+12, 16+11).A; got != 255 {
+			t.Errorf("Draw() pixel of the line alpha = %d, want 255", got)
+		}
+		// nothing is drawn outside of the rectangle
+		for y := 0; y < 64; y++ {
+			for x := 0; x < 64; x++ {
+				if !(image.Point{x, y}).In(r) && img.RGBAAt(x, y).A != 0 {
+					t.Fatalf("Draw() pixel (%d,%d) outside of %v is not transparent", x, y, r)
+				}
+			}
+		}
+	})
+
+	img := image.NewRGBA(image.Rect(0, 0, 24, 24))
+	if err := icons.Draw(img, img.Bounds(), "unknown", icons.RasterOptions{}); err == nil {
+		t.Error("Draw() of an unknown icon error = nil")
+	}
+}
@@ -0,0 +1,129 @@
+package render_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	icons "lucidetest/lucide"
+)
+
+// Icons of testdata/icons by name
+var iconFuncs = map[string]func(...templ.Attributes) templ.Component{
+	"circle":    icons.Circle,
+	"frame":     icons.Frame,
+	"home":      icons.Home,
+	"house":     icons.House,
+	"minus":     icons.Minus,
+	"node":      icons.NodeIcon,
+	"reordered": icons.Reordered,
+	"square":    icons.Square,
+}
+
+// render renders the house icon with attrs.
+func render(attrs ...map[string]any) (string, error) {
+	return renderIcon("house", attrs...)
+}
+
+func renderIcon(name string, attrs ...map[string]any) (string, error) {
+	iconAttrs := []templ.Attributes{}
+	for _, a := range attrs {
+		iconAttrs = append(iconAttrs, a)
+	}
+	var b strings.Builder
+	err := iconFuncs[name](iconAttrs...).Render(context.Background(), &b)
+	return b.String(), err
+}
+
+func TestHouse(t *testing.T) {
+	got, err := render()
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	want := houseOpen +
+		`<path d="M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8"></path> ` +
+		`<path d="M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z"></path></svg>`
+	if got != want {
+		t.Errorf("render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTemplClasses(t *testing.T) {
+	red := templ.ComponentCSSClass{ID: "red", Class: templ.SafeCSS(".red{color:red;}")}
+	runCases(t, []renderCase{
+		{
+			name:  "classes",
+			attrs: []map[string]any{{"class": templ.Classes("nav-icon", templ.KV("rotate-90", true), templ.KV("hidden", false))}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house nav-icon rotate-90">`,
+		},
+		{
+			name:  "key value",
+			attrs: []map[string]any{{"class": templ.KV("active", true)}, {"class": templ.KV("lucide", false)}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="active lucide-house">`,
+		},
+		{
+			name:  "css component",
+			attrs: []map[string]any{{"class": templ.Classes(red, "b")}},
+			want:  `<style type="text/css">.red{color:red;}</style><svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="b lucide lucide-house red">`,
+		},
+	})
+}
+
+func TestTemplValues(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name:  "key values",
+			attrs: []map[string]any{{"aria-current": templ.KV("page", true), "data-off": templ.KV("x", false), "hidden": templ.KV(true, true), "inert": templ.KV(true, false)}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" aria-current="page" hidden>`,
+		},
+	})
+}
+
+func TestTemplStyles(t *testing.T) {
+	runCases(t, []renderCase{
+		{
+			name:  "safe css",
+			attrs: []map[string]any{{"style": templ.SafeCSS("color: red;"), "rotate": 90}, {"style": "margin: 0"}},
+			want:  `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house" style="color: red; margin: 0; transform: rotate(90deg)">`,
+		},
+	})
+}
+
+func TestTemplStylesheet(t *testing.T) {
+	ctx := templ.InitializeContext(templ.WithNonce(context.Background(), `a"b`))
+	page := templ.Join(icons.Styles(), icons.House(templ.Attributes{"animate": "spin"}), icons.Styles())
+	var b strings.Builder
+	if err := page.Render(ctx, &b); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// the stylesheet is rendered once per page, with the nonce of the context
+	want := `<style nonce="a&#34;b">` + icons.AnimationCSS + icons.ThemeCSS + `</style>`
+	if got := b.String(); !strings.HasPrefix(got, want+"<svg") || strings.Count(got, "<style") != 1 {
+		t.Errorf("Render() =\n%s\nwant a single stylesheet\n%s", got, want)
+	}
+
+	// without a nonce
+	b.Reset()
+	if err := icons.Styles().Render(templ.InitializeContext(context.Background()), &b); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := `<style>` + icons.AnimationCSS + icons.ThemeCSS + `</style>`; b.String() != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWithSizeScale(t *testing.T) {
+	icons.SetSizeScale(icons.SizeScale{"sm": {Px: 16}})
+	defer icons.SetSizeScale(icons.DefaultSizeScale())
+	ctx := icons.WithSizeScale(context.Background(), icons.SizeScale{"sm": {Px: 18, StrokeWidth: 1}})
+	var b strings.Builder
+	if err := icons.House(templ.Attributes{"size": "sm"}).Render(ctx, &b); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// the scale of the context overrides the one set by SetSizeScale
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="18" height="18" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-house">`
+	if open := openTag(b.String()); open != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", open, want)
+	}
+}